@@ -0,0 +1,153 @@
+package csclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Machine types found in COFF and PE headers
+const (
+	machineI386  = 0x014c
+	machineAMD64 = 0x8664
+)
+
+// ErrUnknownArch is returned when a payload's architecture cannot be determined
+var ErrUnknownArch = errors.New("unknown payload architecture")
+
+// ArchMismatchError is returned by the architecture preflight when a payload
+// would not run in its target
+type ArchMismatchError struct {
+	BID         string
+	Payload     string // Payload name, e.g. the BOF file key
+	PayloadArch string
+	Target      string // "beacon" or "process <pid>"
+	TargetArch  string
+}
+
+func (e *ArchMismatchError) Error() string {
+	return fmt.Sprintf("%s is %s but %s of beacon %s is %s", e.Payload, e.PayloadArch, e.Target, e.BID, e.TargetArch)
+}
+
+// SetArchPreflight enables checking the architecture of BOFs and injected
+// payloads (DLLs, shellcode, sessions, PowerShell, keylogger and screenshot
+// injection) against the beacon or target process before the task is submitted.
+// Mismatches are returned as *ArchMismatchError without contacting the beacon.
+func (c *Client) SetArchPreflight(enabled bool) {
+	c.archPreflight = enabled
+}
+
+// BinaryArch returns "x86" or "x64" for a COFF object (BOF) or PE image (DLL/EXE)
+func BinaryArch(data []byte) (string, error) {
+	if len(data) < 2 {
+		return "", ErrUnknownArch
+	}
+	machine := binary.LittleEndian.Uint16(data)
+	if data[0] == 'M' && data[1] == 'Z' {
+		if len(data) < 0x40 {
+			return "", ErrUnknownArch
+		}
+		offset := int(binary.LittleEndian.Uint32(data[0x3c:]))
+		if offset < 0 || offset+6 > len(data) || string(data[offset:offset+4]) != "PE\x00\x00" {
+			return "", ErrUnknownArch
+		}
+		machine = binary.LittleEndian.Uint16(data[offset+4:])
+	}
+	switch machine {
+	case machineI386:
+		return "x86", nil
+	case machineAMD64:
+		return "x64", nil
+	}
+	return "", ErrUnknownArch
+}
+
+// PreflightArch checks that payload can run in the beacon's process,
+// regardless of SetArchPreflight. A beacon whose architecture is not x86 or
+// x64 is rejected with a *ValidationError.
+func (c *Client) PreflightArch(ctx context.Context, bid, name string, payload []byte) error {
+	payloadArch, err := BinaryArch(payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	beacon, err := c.GetBeacon(ctx, bid)
+	if err != nil {
+		return err
+	}
+	if beacon.BeaconArch == "" {
+		return &ValidationError{Field: "arch", Value: beacon.BeaconArch, Reason: "beacon architecture is unknown"}
+	}
+	return checkArch(bid, name, payloadArch, "beacon", beacon.BeaconArch)
+}
+
+// preflightBOF checks a BOF attached through @files/ against the beacon when
+// the preflight is enabled. Server-side @artifacts/ BOFs cannot be inspected.
+func (c *Client) preflightBOF(ctx context.Context, bid, bof string, files map[string]string) error {
+	if !c.archPreflight || !strings.HasPrefix(bof, "@files/") {
+		return nil
+	}
+	name := strings.TrimPrefix(bof, "@files/")
+	encoded, ok := files[name]
+	if !ok {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return c.PreflightArch(ctx, bid, name, data)
+}
+
+// preflightInjectPE checks an injected PE payload (DLL) against the target
+// process when the preflight is enabled
+func (c *Client) preflightInjectPE(ctx context.Context, bid, name string, pid int, payload []byte) error {
+	if !c.archPreflight {
+		return nil
+	}
+	payloadArch, err := BinaryArch(payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return c.preflightInject(ctx, bid, name, pid, payloadArch)
+}
+
+// preflightInject checks a payload of payloadArch against the target process
+// architecture when the preflight is enabled. The process architecture is
+// derived from the beacon: its own process, or any process on a 32-bit
+// system. Other processes on 64-bit systems cannot be checked and pass.
+func (c *Client) preflightInject(ctx context.Context, bid, name string, pid int, payloadArch string) error {
+	if !c.archPreflight {
+		return nil
+	}
+	beacon, err := c.GetBeacon(ctx, bid)
+	if err != nil {
+		return err
+	}
+	targetArch := ""
+	switch {
+	case pid == beacon.PID:
+		targetArch = beacon.BeaconArch
+	case strings.EqualFold(beacon.SystemArch, "x86"):
+		targetArch = "x86"
+	}
+	if targetArch == "" {
+		return nil
+	}
+	return checkArch(bid, name, payloadArch, fmt.Sprintf("process %d", pid), targetArch)
+}
+
+// checkArch compares a payload with its target, rejecting target
+// architectures other than x86 and x64 with a *ValidationError
+func checkArch(bid, name, payloadArch, target, targetArch string) error {
+	targetArch = strings.ToLower(targetArch)
+	if targetArch != "x86" && targetArch != "x64" {
+		return &ValidationError{Field: "arch", Value: targetArch, Reason: fmt.Sprintf("architecture of %s must be x86 or x64", target)}
+	}
+	if targetArch == payloadArch {
+		return nil
+	}
+	return &ArchMismatchError{BID: bid, Payload: name, PayloadArch: payloadArch, Target: target, TargetArch: targetArch}
+}
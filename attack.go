@@ -0,0 +1,192 @@
+package csclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TechniqueCoverage summarizes how often an ATT&CK technique was exercised
+type TechniqueCoverage struct {
+	TechniqueID string    `json:"techniqueId"` // e.g. "T1059.003"
+	Count       int       `json:"count"`       // Number of tasks mapped to the technique
+	TaskIDs     []string  `json:"taskIds"`
+	Beacons     []string  `json:"beacons"`
+	Operators   []string  `json:"operators"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+}
+
+// TacticCoverage summarizes the techniques exercised for an ATT&CK tactic
+type TacticCoverage struct {
+	Tactic     string   `json:"tactic"` // e.g. "execution"
+	Count      int      `json:"count"`
+	Techniques []string `json:"techniques"`
+}
+
+// CoverageReport is the ATT&CK coverage of a set of tasks
+type CoverageReport struct {
+	Generated  time.Time            `json:"generated"`
+	TaskCount  int                  `json:"taskCount"`
+	Techniques []*TechniqueCoverage `json:"techniques"`
+	Tactics    []*TacticCoverage    `json:"tactics,omitempty"`
+}
+
+// AttackCoverage builds an ATT&CK coverage report from the tactics recorded on
+// the tasks matching filter. techniqueTactics optionally maps technique IDs to
+// tactic names to produce a per-tactic summary; it may be nil.
+func (c *Client) AttackCoverage(ctx context.Context, filter TaskFilter, techniqueTactics map[string][]string) (*CoverageReport, error) {
+	tasks, err := c.ListTasksFiltered(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	details, err := c.taskDetails(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]TaskDetailDto, 0, len(details))
+	for _, task := range tasks {
+		if detail, ok := details[task.TaskID]; ok {
+			list = append(list, *detail)
+		}
+	}
+	return BuildCoverage(list, techniqueTactics), nil
+}
+
+// BuildCoverage aggregates task tactics into a coverage report.
+// Sub-techniques (T1059.003) also count towards their parent technique lookup
+// in techniqueTactics when they are not listed themselves.
+func BuildCoverage(tasks []TaskDetailDto, techniqueTactics map[string][]string) *CoverageReport {
+	report := &CoverageReport{Generated: time.Now(), TaskCount: len(tasks)}
+	byTechnique := make(map[string]*TechniqueCoverage)
+
+	for _, task := range tasks {
+		for _, id := range task.Tactics {
+			id = strings.ToUpper(strings.TrimSpace(id))
+			if id == "" {
+				continue
+			}
+			tc, ok := byTechnique[id]
+			if !ok {
+				tc = &TechniqueCoverage{TechniqueID: id, FirstSeen: task.Created, LastSeen: task.Created}
+				byTechnique[id] = tc
+			}
+			tc.Count++
+			tc.TaskIDs = append(tc.TaskIDs, task.TaskID)
+			tc.Beacons = appendUnique(tc.Beacons, task.BID)
+			tc.Operators = appendUnique(tc.Operators, task.User)
+			if task.Created.Before(tc.FirstSeen) {
+				tc.FirstSeen = task.Created
+			}
+			if task.Created.After(tc.LastSeen) {
+				tc.LastSeen = task.Created
+			}
+		}
+	}
+
+	for _, tc := range byTechnique {
+		report.Techniques = append(report.Techniques, tc)
+	}
+	sort.Slice(report.Techniques, func(i, j int) bool {
+		return report.Techniques[i].TechniqueID < report.Techniques[j].TechniqueID
+	})
+
+	if len(techniqueTactics) > 0 {
+		byTactic := make(map[string]*TacticCoverage)
+		for _, tc := range report.Techniques {
+			tactics, ok := techniqueTactics[tc.TechniqueID]
+			if !ok {
+				parent, _, _ := strings.Cut(tc.TechniqueID, ".")
+				tactics = techniqueTactics[parent]
+			}
+			for _, tactic := range tactics {
+				cov, ok := byTactic[tactic]
+				if !ok {
+					cov = &TacticCoverage{Tactic: tactic}
+					byTactic[tactic] = cov
+				}
+				cov.Count += tc.Count
+				cov.Techniques = appendUnique(cov.Techniques, tc.TechniqueID)
+			}
+		}
+		for _, cov := range byTactic {
+			report.Tactics = append(report.Tactics, cov)
+		}
+		sort.Slice(report.Tactics, func(i, j int) bool {
+			return report.Tactics[i].Tactic < report.Tactics[j].Tactic
+		})
+	}
+
+	return report
+}
+
+// appendUnique appends s unless it is empty or already present
+func appendUnique(list []string, s string) []string {
+	if s == "" {
+		return list
+	}
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// navigatorLayer is the subset of the ATT&CK Navigator layer format written by WriteNavigatorLayer
+type navigatorLayer struct {
+	Name        string               `json:"name"`
+	Versions    map[string]string    `json:"versions"`
+	Domain      string               `json:"domain"`
+	Description string               `json:"description"`
+	Techniques  []navigatorTechnique `json:"techniques"`
+	Gradient    navigatorGradient    `json:"gradient"`
+}
+
+type navigatorTechnique struct {
+	TechniqueID string `json:"techniqueID"`
+	Score       int    `json:"score"`
+	Comment     string `json:"comment,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type navigatorGradient struct {
+	Colors   []string `json:"colors"`
+	MinValue int      `json:"minValue"`
+	MaxValue int      `json:"maxValue"`
+}
+
+// WriteNavigatorLayer writes the report as an ATT&CK Navigator layer,
+// scoring each technique by the number of tasks that exercised it
+func (r *CoverageReport) WriteNavigatorLayer(w io.Writer, name string) error {
+	layer := navigatorLayer{
+		Name:        name,
+		Versions:    map[string]string{"layer": "4.5", "navigator": "4.9.1"},
+		Domain:      "enterprise-attack",
+		Description: fmt.Sprintf("Generated from %d Cobalt Strike tasks on %s", r.TaskCount, r.Generated.Format(time.RFC3339)),
+		Gradient:    navigatorGradient{Colors: []string{"#ffe766", "#ff6666"}, MinValue: 1},
+	}
+	for _, tc := range r.Techniques {
+		layer.Techniques = append(layer.Techniques, navigatorTechnique{
+			TechniqueID: tc.TechniqueID,
+			Score:       tc.Count,
+			Comment:     fmt.Sprintf("%d task(s) on %d beacon(s)", tc.Count, len(tc.Beacons)),
+			Enabled:     true,
+		})
+		if tc.Count > layer.Gradient.MaxValue {
+			layer.Gradient.MaxValue = tc.Count
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(layer); err != nil {
+		return fmt.Errorf("failed to write navigator layer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandSpec describes the command submitted to every beacon of a batch
+type CommandSpec struct {
+	Name   string                                                                          // Label used in errors; defaults to the console command
+	Submit func(ctx context.Context, c *Client, bid string) (*AsyncCommandResponse, error) // Submits the command to one beacon
+}
+
+// ConsoleCommand returns a spec running a console command on each beacon
+func ConsoleCommand(cmd CommandDto) CommandSpec {
+	return CommandSpec{
+		Name: strings.TrimSpace(cmd.Command + " " + cmd.Arguments),
+		Submit: func(ctx context.Context, c *Client, bid string) (*AsyncCommandResponse, error) {
+			return c.ExecuteConsoleCommand(ctx, bid, cmd)
+		},
+	}
+}
+
+// ShellCommand returns a spec running a shell command on each beacon
+func ShellCommand(command string) CommandSpec {
+	return CommandSpec{
+		Name: "shell " + command,
+		Submit: func(ctx context.Context, c *Client, bid string) (*AsyncCommandResponse, error) {
+			return c.ExecuteShell(ctx, bid, command)
+		},
+	}
+}
+
+// BatchOption configures ExecuteOnBeacons
+type BatchOption func(*batchConfig)
+
+// batchConfig holds the settings used by ExecuteOnBeacons
+type batchConfig struct {
+	concurrency int
+	rate        float64
+	wait        bool
+	waitOpts    []WaitOption
+}
+
+// WithBatchConcurrency caps the number of submissions in flight
+func WithBatchConcurrency(n int) BatchOption {
+	return func(cfg *batchConfig) { cfg.concurrency = n }
+}
+
+// WithBatchRateLimit caps submissions per second across the batch
+func WithBatchRateLimit(requestsPerSecond float64) BatchOption {
+	return func(cfg *batchConfig) { cfg.rate = requestsPerSecond }
+}
+
+// WithBatchWait waits for the submitted tasks to complete, polling them with WaitForTasks
+func WithBatchWait(opts ...WaitOption) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.wait = true
+		cfg.waitOpts = opts
+	}
+}
+
+// BeaconResult is the outcome of a batch command on one beacon
+type BeaconResult struct {
+	Beacon   BeaconDto
+	Response *AsyncCommandResponse // Nil if submission failed
+	Task     *TaskDetailDto        // Set when waiting and the task finished
+	Err      error                 // Submission or wait error
+}
+
+// BatchResult holds the per-beacon results of ExecuteOnBeacons, ordered by beacon ID
+type BatchResult struct {
+	Results []BeaconResult
+}
+
+// Succeeded returns the results without an error whose task, if waited on, did not fail
+func (r *BatchResult) Succeeded() []BeaconResult {
+	var ok []BeaconResult
+	for _, res := range r.Results {
+		if res.Err == nil && (res.Task == nil || res.Task.TaskStatus != TaskStatusFailed) {
+			ok = append(ok, res)
+		}
+	}
+	return ok
+}
+
+// Failed returns the results with an error or a failed task
+func (r *BatchResult) Failed() []BeaconResult {
+	var failed []BeaconResult
+	for _, res := range r.Results {
+		if res.Err != nil || (res.Task != nil && res.Task.TaskStatus == TaskStatusFailed) {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// BatchError reports a batch in which some beacons failed
+type BatchError struct {
+	Command string
+	Failed  int
+	Total   int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%q failed on %d of %d beacons", e.Command, e.Failed, e.Total)
+}
+
+// ExecuteOnBeacons submits a command to every beacon matching filter using a
+// bounded worker pool. The result always contains one entry per beacon; when
+// some beacons fail a *BatchError is returned alongside it.
+func (c *Client) ExecuteOnBeacons(ctx context.Context, filter BeaconFilter, cmd CommandSpec, opts ...BatchOption) (*BatchResult, error) {
+	if cmd.Submit == nil {
+		return nil, fmt.Errorf("failed to execute on beacons: command spec has no Submit function")
+	}
+	cfg := batchConfig{
+		concurrency: 8,
+		rate:        10,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
+	}
+
+	beacons, err := c.SelectBeacons(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute on beacons: %w", err)
+	}
+	sort.Slice(beacons, func(i, j int) bool { return beacons[i].BID < beacons[j].BID })
+
+	var limiter <-chan time.Time
+	if cfg.rate > 0 {
+		// Rates above one per nanosecond would truncate the interval to zero
+		interval := time.Duration(float64(time.Second) / cfg.rate)
+		if interval < 1 {
+			interval = 1
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	result := &BatchResult{Results: make([]BeaconResult, len(beacons))}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.concurrency && w < len(beacons); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := &result.Results[i]
+				res.Beacon = beacons[i]
+				if limiter != nil {
+					select {
+					case <-limiter:
+					case <-ctx.Done():
+						res.Err = ctx.Err()
+						continue
+					}
+				}
+				res.Response, res.Err = cmd.Submit(ctx, c, beacons[i].BID)
+			}
+		}()
+	}
+	for i := range beacons {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if cfg.wait {
+		var taskIDs []string
+		for _, res := range result.Results {
+			if res.Err == nil && res.Response != nil && res.Response.TaskID != "" {
+				taskIDs = append(taskIDs, res.Response.TaskID)
+			}
+		}
+		done, waitErr := c.WaitForTasks(ctx, taskIDs, cfg.waitOpts...)
+		for i := range result.Results {
+			res := &result.Results[i]
+			if res.Err != nil || res.Response == nil {
+				continue
+			}
+			if task, ok := done[res.Response.TaskID]; ok {
+				res.Task = task
+			} else if waitErr != nil {
+				res.Err = waitErr
+			} else {
+				res.Err = fmt.Errorf("no task ID returned")
+			}
+		}
+	}
+
+	if failed := len(result.Failed()); failed > 0 {
+		name := cmd.Name
+		if name == "" {
+			name = "command"
+		}
+		return result, &BatchError{Command: name, Failed: failed, Total: len(result.Results)}
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package csclient
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BeaconColumn is a column of a beacon inventory export
+type BeaconColumn struct {
+	Name  string
+	Value func(b *BeaconDto) string
+}
+
+// beaconColumns are the columns available to ExportBeaconsColumns, in default order
+var beaconColumns = []BeaconColumn{
+	{"bid", func(b *BeaconDto) string { return b.BID }},
+	{"pbid", func(b *BeaconDto) string { return b.PBID }},
+	{"computer", func(b *BeaconDto) string { return b.Computer }},
+	{"user", func(b *BeaconDto) string { return b.User }},
+	{"impersonated", func(b *BeaconDto) string { return b.Impersonated }},
+	{"admin", func(b *BeaconDto) string { return strconv.FormatBool(b.IsAdmin) }},
+	{"process", func(b *BeaconDto) string { return b.Process }},
+	{"pid", func(b *BeaconDto) string { return strconv.Itoa(b.PID) }},
+	{"internal", func(b *BeaconDto) string { return b.Internal }},
+	{"external", func(b *BeaconDto) string { return b.External }},
+	{"os", func(b *BeaconDto) string { return strings.TrimSpace(b.OS + " " + b.Version) }},
+	{"arch", func(b *BeaconDto) string { return b.BeaconArch }},
+	{"listener", func(b *BeaconDto) string { return b.Listener }},
+	{"session", func(b *BeaconDto) string { return b.Session }},
+	{"alive", func(b *BeaconDto) string { return strconv.FormatBool(b.Alive) }},
+	{"last_checkin", func(b *BeaconDto) string { return b.LastCheckinTime.Format(time.RFC3339) }},
+	{"sleep", func(b *BeaconDto) string { return fmt.Sprintf("%ds/%d%%", b.Sleep.Sleep, b.Sleep.Jitter) }},
+	{"note", func(b *BeaconDto) string { return b.Note }},
+}
+
+// DefaultBeaconColumns are the columns written by ExportBeacons
+var DefaultBeaconColumns = []string{
+	"bid", "computer", "user", "admin", "process", "pid", "internal", "external", "os", "arch", "listener", "alive", "last_checkin",
+}
+
+// BeaconColumnNames returns the names of every column available for beacon exports
+func BeaconColumnNames() []string {
+	names := make([]string, len(beaconColumns))
+	for i, col := range beaconColumns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// ExportBeacons writes an inventory of the beacons matching filter to w using DefaultBeaconColumns
+func (c *Client) ExportBeacons(ctx context.Context, w io.Writer, format Format, filter BeaconFilter) error {
+	return c.ExportBeaconsColumns(ctx, w, format, filter, DefaultBeaconColumns)
+}
+
+// ExportBeaconsColumns writes an inventory of the beacons matching filter to w
+// with the given columns (see BeaconColumnNames) as CSV, JSON, JSON lines or a Markdown table
+func (c *Client) ExportBeaconsColumns(ctx context.Context, w io.Writer, format Format, filter BeaconFilter, columns []string) error {
+	cols, err := lookupBeaconColumns(columns)
+	if err != nil {
+		return err
+	}
+	beacons, err := c.SelectBeacons(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if err := writeBeaconInventory(w, format, beacons, cols); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+func lookupBeaconColumns(names []string) ([]BeaconColumn, error) {
+	cols := make([]BeaconColumn, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, col := range beaconColumns {
+			if col.Name == name {
+				cols = append(cols, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, &ValidationError{Field: "column", Value: name, Reason: "unknown beacon column"}
+		}
+	}
+	return cols, nil
+}
+
+func writeBeaconInventory(w io.Writer, format Format, beacons []BeaconDto, cols []BeaconColumn) error {
+	rows := make([][]string, len(beacons))
+	for i := range beacons {
+		row := make([]string, len(cols))
+		for j, col := range cols {
+			row[j] = col.Value(&beacons[i])
+		}
+		rows[i] = row
+	}
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.Name
+	}
+
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		return cw.WriteAll(rows)
+	case FormatJSON, FormatJSONL:
+		objects := make([]map[string]string, len(rows))
+		for i, row := range rows {
+			obj := make(map[string]string, len(cols))
+			for j, name := range header {
+				obj[name] = row[j]
+			}
+			objects[i] = obj
+		}
+		enc := json.NewEncoder(w)
+		if format == FormatJSON {
+			enc.SetIndent("", "  ")
+			return enc.Encode(objects)
+		}
+		for _, obj := range objects {
+			if err := enc.Encode(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatMarkdown:
+		var sb strings.Builder
+		writeMarkdownRow(&sb, header)
+		sb.WriteString("|")
+		for range header {
+			sb.WriteString(" --- |")
+		}
+		sb.WriteString("\n")
+		for _, row := range rows {
+			writeMarkdownRow(&sb, row)
+		}
+		_, err := io.WriteString(w, sb.String())
+		return err
+	default:
+		return fmt.Errorf("unsupported beacon export format %q", format)
+	}
+}
+
+// writeMarkdownRow writes a table row, escaping pipes and flattening newlines
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\r", ""), "\n", " ")
+		sb.WriteString(" " + cell + " |")
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,92 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BeaconQuery narrows beacon listings. Fields are sent to the server as query
+// parameters and also applied client-side, so results are correct whether or
+// not the server honours them. Zero-value fields do not filter.
+type BeaconQuery struct {
+	AliveOnly bool   // Only beacons that are alive
+	Listener  string // Listener name (case-insensitive)
+	User      string // Beacon user; a trailing " *" (elevated marker) is ignored (case-insensitive)
+	Subnet    string // CIDR that the internal address must belong to, e.g. "10.1.0.0/16"
+	OS        string // Substring of the OS name (case-insensitive)
+}
+
+// query returns the beacon query as URL query parameters
+func (q BeaconQuery) query() url.Values {
+	v := url.Values{}
+	if q.AliveOnly {
+		v.Set("alive", strconv.FormatBool(true))
+	}
+	if q.Listener != "" {
+		v.Set("listener", q.Listener)
+	}
+	if q.User != "" {
+		v.Set("user", q.User)
+	}
+	if q.Subnet != "" {
+		v.Set("subnet", q.Subnet)
+	}
+	if q.OS != "" {
+		v.Set("os", q.OS)
+	}
+	return v
+}
+
+// Matches reports whether a beacon satisfies the query.
+// An invalid Subnet matches nothing; ListBeaconsMatching reports it as an error.
+func (q BeaconQuery) Matches(b *BeaconDto) bool {
+	if q.AliveOnly && !b.Alive {
+		return false
+	}
+	if q.Listener != "" && !strings.EqualFold(b.Listener, q.Listener) {
+		return false
+	}
+	if q.User != "" && !strings.EqualFold(strings.TrimSuffix(b.User, " *"), strings.TrimSuffix(q.User, " *")) {
+		return false
+	}
+	if q.OS != "" && !strings.Contains(strings.ToLower(b.OS), strings.ToLower(q.OS)) {
+		return false
+	}
+	if q.Subnet != "" {
+		_, network, err := net.ParseCIDR(q.Subnet)
+		if err != nil {
+			return false
+		}
+		ip := net.ParseIP(b.Internal)
+		if ip == nil || !network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListBeaconsMatching retrieves the beacons matching the query
+func (c *Client) ListBeaconsMatching(ctx context.Context, q BeaconQuery) ([]BeaconDto, error) {
+	if q.Subnet != "" {
+		if _, _, err := net.ParseCIDR(q.Subnet); err != nil {
+			return nil, fmt.Errorf("failed to list beacons: %w", &ValidationError{Field: "subnet", Value: q.Subnet, Reason: "must be a CIDR"})
+		}
+	}
+
+	var beacons []BeaconDto
+	if err := c.doRequest(ctx, "GET", withQuery("/api/v1/beacons", q.query()), nil, &beacons, true); err != nil {
+		return nil, fmt.Errorf("failed to list beacons: %w", err)
+	}
+
+	matched := beacons[:0]
+	for i := range beacons {
+		if q.Matches(&beacons[i]) {
+			matched = append(matched, beacons[i])
+		}
+	}
+	return matched, nil
+}
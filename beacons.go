@@ -3,6 +3,7 @@ package csclient
 import (
 	"context"
 	"fmt"
+	"strconv"
 )
 
 // ListBeacons retrieves all beacons
@@ -17,7 +18,11 @@ func (c *Client) ListBeacons(ctx context.Context) ([]BeaconDto, error) {
 // GetBeacon retrieves information about a specific beacon
 func (c *Client) GetBeacon(ctx context.Context, bid string) (*BeaconDto, error) {
 	var beacon BeaconDto
-	if err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/beacons/%s", bid), nil, &beacon, true); err != nil {
+	path, err := beaconPath(bid, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &beacon, true); err != nil {
 		return nil, fmt.Errorf("failed to get beacon: %w", err)
 	}
 	return &beacon, nil
@@ -26,7 +31,13 @@ func (c *Client) GetBeacon(ctx context.Context, bid string) (*BeaconDto, error)
 // ExecuteBOFString executes a BOF with string arguments
 func (c *Client) ExecuteBOFString(ctx context.Context, bid string, req InlineExecuteStringDto) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/bof/string", bid)
+	path, err := beaconPath(bid, "/execute/bof/string")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
+	if err := c.preflightBOF(ctx, bid, req.BOF, req.Files); err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute BOF: %w", err)
 	}
@@ -36,7 +47,13 @@ func (c *Client) ExecuteBOFString(ctx context.Context, bid string, req InlineExe
 // ExecuteBOFPacked executes a BOF with packed arguments
 func (c *Client) ExecuteBOFPacked(ctx context.Context, bid string, req InlineExecutePackedDto) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/bof/packed", bid)
+	path, err := beaconPath(bid, "/execute/bof/packed")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
+	if err := c.preflightBOF(ctx, bid, req.BOF, req.Files); err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute BOF: %w", err)
 	}
@@ -46,7 +63,16 @@ func (c *Client) ExecuteBOFPacked(ctx context.Context, bid string, req InlineExe
 // ExecuteBOFPack executes a BOF with typed arguments
 func (c *Client) ExecuteBOFPack(ctx context.Context, bid string, req InlineExecutePackDto) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/bof/pack", bid)
+	path, err := beaconPath(bid, "/execute/bof/pack")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
+	if err := validateBOFArguments(req.Arguments); err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
+	if err := c.preflightBOF(ctx, bid, req.BOF, req.Files); err != nil {
+		return nil, fmt.Errorf("failed to execute BOF: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute BOF: %w", err)
 	}
@@ -56,7 +82,10 @@ func (c *Client) ExecuteBOFPack(ctx context.Context, bid string, req InlineExecu
 // GetUID executes the getuid command (whoami equivalent)
 func (c *Client) GetUID(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/getUid", bid)
+	path, err := beaconPath(bid, "/execute/getUid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute getuid: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute getuid: %w", err)
 	}
@@ -66,9 +95,140 @@ func (c *Client) GetUID(ctx context.Context, bid string) (*AsyncCommandResponse,
 // GetSystem attempts to elevate to SYSTEM
 func (c *Client) GetSystem(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/getSystem", bid)
+	path, err := beaconPath(bid, "/execute/getSystem")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute getsystem: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute getsystem: %w", err)
 	}
 	return &resp, nil
 }
+
+// GetBeaconQueue returns the beacon's tasks that have not yet been delivered,
+// i.e. tasks still in progress that the beacon has not acknowledged
+func (c *Client) GetBeaconQueue(ctx context.Context, bid string) ([]TaskDetailDto, error) {
+	tasks, err := c.GetBeaconTasksDetail(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	var queued []TaskDetailDto
+	for _, task := range tasks {
+		if task.TaskStatus == TaskStatusInProgress && len(task.TaskAcknowledgements) == 0 {
+			queued = append(queued, task)
+		}
+	}
+	return queued, nil
+}
+
+// ClearBeaconQueue clears the beacon's queue of commands not yet sent to the beacon
+func (c *Client) ClearBeaconQueue(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/clearCommandQueue")
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear command queue: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to clear command queue: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetBeaconNote assigns a note to the beacon
+func (c *Client) SetBeaconNote(ctx context.Context, bid, note string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/note")
+	if err != nil {
+		return nil, fmt.Errorf("failed to set note: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, NoteDto{Note: note}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to set note: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetSleep changes the beacon's check-in interval (seconds) and jitter (percentage, 0-99)
+func (c *Client) SetSleep(ctx context.Context, bid string, sleep, jitter int) (*AsyncCommandResponse, error) {
+	if sleep < 0 {
+		return nil, fmt.Errorf("failed to set sleep: %w", &ValidationError{Field: "sleep", Value: strconv.Itoa(sleep), Reason: "must not be negative"})
+	}
+	if jitter < 0 || jitter > 99 {
+		return nil, fmt.Errorf("failed to set sleep: %w", &ValidationError{Field: "jitter", Value: strconv.Itoa(jitter), Reason: "must be between 0 and 99"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/sleepTime")
+	if err != nil {
+		return nil, fmt.Errorf("failed to set sleep: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, SleepDto{Sleep: sleep, Jitter: jitter}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to set sleep: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetInteractive puts the beacon in interactive mode (sleep 0, no jitter)
+func (c *Client) SetInteractive(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	return c.SetSleep(ctx, bid, 0, 0)
+}
+
+// ExitBeacon tasks the beacon to exit gracefully
+func (c *Client) ExitBeacon(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/exit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to exit beacon: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to exit beacon: %w", err)
+	}
+	return &resp, nil
+}
+
+// RemoveBeacon removes the beacon from the team server's data model. Active
+// beacons should be exited first or they reappear at their next checkin.
+func (c *Client) RemoveBeacon(ctx context.Context, bid string) error {
+	path, err := beaconPath(bid, "")
+	if err != nil {
+		return fmt.Errorf("failed to remove beacon: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", path, nil, nil, true); err != nil {
+		return fmt.Errorf("failed to remove beacon: %w", err)
+	}
+	return nil
+}
+
+// Checkin asks a DNS beacon to call home and send its metadata
+func (c *Client) Checkin(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/checkIn")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute checkin: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute checkin: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetBeaconMode sets the data channel of a DNS beacon to DnsModeA, DnsModeAAAA or DnsModeTXT
+func (c *Client) SetBeaconMode(ctx context.Context, bid, mode string) (*AsyncCommandResponse, error) {
+	switch mode {
+	case DnsModeA, DnsModeAAAA, DnsModeTXT:
+	case "dns-txt":
+		// Console name of the TXT channel; the API schema only accepts dnsTxt
+		mode = DnsModeTXT
+	default:
+		return nil, fmt.Errorf("failed to set beacon mode: %w", &ValidationError{Field: "mode", Value: mode, Reason: "must be dns, dns6 or dnsTxt"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/dnsMode")
+	if err != nil {
+		return nil, fmt.Errorf("failed to set beacon mode: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, DnsModeDto{Mode: mode}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to set beacon mode: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,154 @@
+package csclient
+
+import (
+	"context"
+	"time"
+)
+
+// BeaconEventType identifies a beacon lifecycle event
+type BeaconEventType string
+
+const (
+	BeaconEventNew        BeaconEventType = "new"        // Beacon appeared
+	BeaconEventDead       BeaconEventType = "dead"       // Beacon is no longer alive
+	BeaconEventRevived    BeaconEventType = "revived"    // A dead beacon is alive again
+	BeaconEventRemoved    BeaconEventType = "removed"    // Beacon disappeared from the listing
+	BeaconEventChanged    BeaconEventType = "changed"    // Metadata (elevation, impersonation, ...) changed
+	BeaconEventReparented BeaconEventType = "reparented" // Parent beacon (pivot) changed
+	BeaconEventError      BeaconEventType = "error"      // Listing failed; watching continues
+)
+
+// BeaconEvent is emitted by WatchBeacons
+type BeaconEvent struct {
+	Type     BeaconEventType
+	Time     time.Time
+	Beacon   *BeaconDto // Current state (last known state for removed beacons)
+	Previous *BeaconDto // Previous state for dead, revived, changed and reparented events
+	Changes  []string   // Names of the changed fields for changed events
+	Err      error      // Set for error events
+}
+
+// WatchBeacons lists beacons every interval and emits lifecycle events by
+// comparing successive snapshots. Beacons present when watching starts produce
+// a new event on the first poll. The channel is closed when ctx ends.
+func (c *Client) WatchBeacons(ctx context.Context, interval time.Duration) (<-chan BeaconEvent, error) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	events := make(chan BeaconEvent)
+	go func() {
+		defer close(events)
+		known := make(map[string]BeaconDto)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		emit := func(e BeaconEvent) bool {
+			e.Time = time.Now()
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			beacons, err := c.ListBeacons(ctx)
+			if err != nil {
+				if ctx.Err() != nil || !emit(BeaconEvent{Type: BeaconEventError, Err: err}) {
+					return
+				}
+			} else {
+				for _, e := range diffBeacons(known, beacons) {
+					if !emit(e) {
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// WatchBeaconsFunc runs WatchBeacons and calls fn for every event until ctx ends
+func (c *Client) WatchBeaconsFunc(ctx context.Context, interval time.Duration, fn func(BeaconEvent)) error {
+	events, err := c.WatchBeacons(ctx, interval)
+	if err != nil {
+		return err
+	}
+	for e := range events {
+		fn(e)
+	}
+	return ctx.Err()
+}
+
+// diffBeacons compares a new listing against the known state, updates the
+// known state and returns the resulting events
+func diffBeacons(known map[string]BeaconDto, beacons []BeaconDto) []BeaconEvent {
+	var events []BeaconEvent
+	seen := make(map[string]bool, len(beacons))
+
+	for i := range beacons {
+		current := beacons[i]
+		seen[current.BID] = true
+		previous, ok := known[current.BID]
+		known[current.BID] = current
+
+		if !ok {
+			events = append(events, BeaconEvent{Type: BeaconEventNew, Beacon: &current})
+			continue
+		}
+		prev := previous
+		if previous.Alive && !current.Alive {
+			events = append(events, BeaconEvent{Type: BeaconEventDead, Beacon: &current, Previous: &prev})
+		} else if !previous.Alive && current.Alive {
+			events = append(events, BeaconEvent{Type: BeaconEventRevived, Beacon: &current, Previous: &prev})
+		}
+		if previous.PBID != current.PBID {
+			events = append(events, BeaconEvent{Type: BeaconEventReparented, Beacon: &current, Previous: &prev})
+		}
+		if changes := beaconChanges(&previous, &current); len(changes) > 0 {
+			events = append(events, BeaconEvent{Type: BeaconEventChanged, Beacon: &current, Previous: &prev, Changes: changes})
+		}
+	}
+
+	for bid, previous := range known {
+		if !seen[bid] {
+			last := previous
+			delete(known, bid)
+			events = append(events, BeaconEvent{Type: BeaconEventRemoved, Beacon: &last})
+		}
+	}
+	return events
+}
+
+// beaconChanges lists the metadata fields that differ between two beacon snapshots.
+// Check-in times, liveness and parent changes are reported by other events.
+func beaconChanges(a, b *BeaconDto) []string {
+	var changes []string
+	check := func(name string, changed bool) {
+		if changed {
+			changes = append(changes, name)
+		}
+	}
+	check("user", a.User != b.User)
+	check("impersonated", a.Impersonated != b.Impersonated)
+	check("isAdmin", a.IsAdmin != b.IsAdmin)
+	check("process", a.Process != b.Process || a.PID != b.PID)
+	check("internal", a.Internal != b.Internal)
+	check("external", a.External != b.External)
+	check("linkState", a.LinkState != b.LinkState)
+	check("sleep", a.Sleep != b.Sleep)
+	check("note", a.Note != b.Note)
+	check("color", a.Color != b.Color)
+	check("listener", a.Listener != b.Listener)
+	return changes
+}
@@ -0,0 +1,227 @@
+package csclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// BloodHoundOptions controls how beacon metadata is mapped to BloodHound objects
+type BloodHoundOptions struct {
+	// Domain is the AD domain FQDN (e.g. CORP.LOCAL) used for object names.
+	// NetBIOS domains found in beacon user names are replaced by it.
+	Domain string
+	// SIDs maps upper-case object names (WS01.CORP.LOCAL, ALICE@CORP.LOCAL) to
+	// their SIDs. Beacons carry no SIDs, so unmapped objects are identified by
+	// name and will not merge with SharpHound-collected nodes.
+	SIDs map[string]string
+}
+
+// BloodHoundData holds the computers and users derived from a beacon inventory
+// in the SharpHound JSON format (version 5)
+type BloodHoundData struct {
+	Computers []BloodHoundComputer
+	Users     []BloodHoundUser
+}
+
+// BloodHoundComputer is a SharpHound computer object
+type BloodHoundComputer struct {
+	ObjectIdentifier string                 `json:"ObjectIdentifier"`
+	Properties       map[string]interface{} `json:"Properties"`
+	Sessions         BloodHoundResults      `json:"Sessions"`
+	LocalAdmins      BloodHoundResults      `json:"LocalAdmins"`
+	Aces             []interface{}          `json:"Aces"`
+	IsDeleted        bool                   `json:"IsDeleted"`
+	IsACLProtected   bool                   `json:"IsACLProtected"`
+}
+
+// BloodHoundUser is a SharpHound user object
+type BloodHoundUser struct {
+	ObjectIdentifier string                 `json:"ObjectIdentifier"`
+	Properties       map[string]interface{} `json:"Properties"`
+	Aces             []interface{}          `json:"Aces"`
+	IsDeleted        bool                   `json:"IsDeleted"`
+	IsACLProtected   bool                   `json:"IsACLProtected"`
+}
+
+// BloodHoundResults is a collected SharpHound relationship list
+type BloodHoundResults struct {
+	Results   []interface{} `json:"Results"`
+	Collected bool          `json:"Collected"`
+}
+
+type bloodHoundSession struct {
+	UserSID     string `json:"UserSID"`
+	ComputerSID string `json:"ComputerSID"`
+}
+
+type bloodHoundMember struct {
+	ObjectIdentifier string `json:"ObjectIdentifier"`
+	ObjectType       string `json:"ObjectType"`
+}
+
+type bloodHoundFile struct {
+	Data interface{}    `json:"data"`
+	Meta bloodHoundMeta `json:"meta"`
+}
+
+type bloodHoundMeta struct {
+	Methods int    `json:"methods"`
+	Type    string `json:"type"`
+	Count   int    `json:"count"`
+	Version int    `json:"version"`
+}
+
+// BloodHoundExport converts the beacons matching filter into BloodHound computers
+// (with sessions and local admin rights) and users
+func (c *Client) BloodHoundExport(ctx context.Context, filter BeaconFilter, opts BloodHoundOptions) (*BloodHoundData, error) {
+	beacons, err := c.SelectBeacons(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return BuildBloodHound(beacons, opts), nil
+}
+
+// BuildBloodHound converts beacons into BloodHound computers and users. Local
+// and built-in accounts (SYSTEM, services) are not AD principals and only
+// contribute the computer node.
+func BuildBloodHound(beacons []BeaconDto, opts BloodHoundOptions) *BloodHoundData {
+	domain := strings.ToUpper(opts.Domain)
+	id := func(name string) string {
+		if sid, ok := opts.SIDs[name]; ok {
+			return sid
+		}
+		return name
+	}
+
+	computers := make(map[string]*BloodHoundComputer)
+	users := make(map[string]*BloodHoundUser)
+	sessions := make(map[string]map[string]bool)
+	admins := make(map[string]map[string]bool)
+
+	for i := range beacons {
+		b := &beacons[i]
+		if b.Computer == "" {
+			continue
+		}
+		computerName := strings.ToUpper(b.Computer)
+		if domain != "" {
+			computerName += "." + domain
+		}
+		computer, ok := computers[computerName]
+		if !ok {
+			computer = &BloodHoundComputer{
+				ObjectIdentifier: id(computerName),
+				Properties: map[string]interface{}{
+					"name":            computerName,
+					"domain":          domain,
+					"operatingsystem": strings.TrimSpace(b.OS + " " + b.Version),
+					"enabled":         true,
+				},
+				Sessions:    BloodHoundResults{Results: []interface{}{}, Collected: true},
+				LocalAdmins: BloodHoundResults{Results: []interface{}{}, Collected: true},
+				Aces:        []interface{}{},
+			}
+			computers[computerName] = computer
+			sessions[computerName] = make(map[string]bool)
+			admins[computerName] = make(map[string]bool)
+		}
+
+		userName, ok := bloodHoundUserName(b.User, b.Computer, domain)
+		if !ok {
+			continue
+		}
+		user, ok := users[userName]
+		if !ok {
+			user = &BloodHoundUser{
+				ObjectIdentifier: id(userName),
+				Properties: map[string]interface{}{
+					"name":    userName,
+					"domain":  domain,
+					"enabled": true,
+				},
+				Aces: []interface{}{},
+			}
+			users[userName] = user
+		}
+		if !sessions[computerName][user.ObjectIdentifier] {
+			sessions[computerName][user.ObjectIdentifier] = true
+			computer.Sessions.Results = append(computer.Sessions.Results, bloodHoundSession{
+				UserSID:     user.ObjectIdentifier,
+				ComputerSID: computer.ObjectIdentifier,
+			})
+		}
+		if b.IsAdmin && !admins[computerName][user.ObjectIdentifier] {
+			admins[computerName][user.ObjectIdentifier] = true
+			computer.LocalAdmins.Results = append(computer.LocalAdmins.Results, bloodHoundMember{
+				ObjectIdentifier: user.ObjectIdentifier,
+				ObjectType:       "User",
+			})
+		}
+	}
+
+	data := &BloodHoundData{}
+	for _, name := range sortedKeys(computers) {
+		data.Computers = append(data.Computers, *computers[name])
+	}
+	for _, name := range sortedKeys(users) {
+		data.Users = append(data.Users, *users[name])
+	}
+	return data
+}
+
+// bloodHoundUserName converts a beacon user ("CORP\alice *") into a BloodHound
+// user name (ALICE@CORP.LOCAL). ok is false for local and built-in accounts.
+func bloodHoundUserName(user, computer, domain string) (string, bool) {
+	user = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(user), "*"))
+	netbios, name, found := strings.Cut(user, `\`)
+	if !found || name == "" {
+		return "", false
+	}
+	netbios = strings.ToUpper(netbios)
+	if netbios == strings.ToUpper(computer) || netbios == "NT AUTHORITY" || netbios == "NT SERVICE" || netbios == "WORKGROUP" {
+		return "", false
+	}
+	if domain == "" {
+		domain = netbios
+	}
+	return strings.ToUpper(name) + "@" + domain, true
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteComputers writes the computers as a SharpHound computers.json file
+func (d *BloodHoundData) WriteComputers(w io.Writer) error {
+	computers := d.Computers
+	if computers == nil {
+		computers = []BloodHoundComputer{}
+	}
+	return writeBloodHoundFile(w, "computers", computers, len(computers))
+}
+
+// WriteUsers writes the users as a SharpHound users.json file
+func (d *BloodHoundData) WriteUsers(w io.Writer) error {
+	users := d.Users
+	if users == nil {
+		users = []BloodHoundUser{}
+	}
+	return writeBloodHoundFile(w, "users", users, len(users))
+}
+
+func writeBloodHoundFile(w io.Writer, kind string, data interface{}, count int) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bloodHoundFile{
+		Data: data,
+		Meta: bloodHoundMeta{Type: kind, Count: count, Version: 5},
+	})
+}
@@ -0,0 +1,118 @@
+package csclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// ExecuteBOFFile reads a local BOF object file and executes it with typed
+// arguments, attaching the file to the request. An empty entrypoint uses "go".
+// The file is checked with ValidateBOF before it is submitted.
+func (c *Client) ExecuteBOFFile(ctx context.Context, bid, path, entrypoint string, args ...BOFArgument) (*AsyncCommandResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BOF: %w", err)
+	}
+	if entrypoint == "" {
+		entrypoint = "go"
+	}
+	if _, err := ValidateBOF(data, entrypoint); err != nil {
+		return nil, fmt.Errorf("failed to execute BOF %s: %w", filepath.Base(path), err)
+	}
+	ref, files := attachFile(path, data)
+	return c.ExecuteBOFPack(ctx, bid, InlineExecutePackDto{
+		BOF:        ref,
+		Entrypoint: entrypoint,
+		Arguments:  args,
+		Files:      files,
+	})
+}
+
+// BOF argument type names expected by the API
+const (
+	BOFArgTypeBinary  = "binary"
+	BOFArgTypeInt     = "int"
+	BOFArgTypeShort   = "short"
+	BOFArgTypeString  = "string"
+	BOFArgTypeWString = "wstring"
+)
+
+// NewIntArg returns a 32-bit integer argument. Values up to 4294967295 are
+// accepted for BOFs that read the int as unsigned.
+func NewIntArg(v int) (IntArg, error) {
+	if int64(v) < math.MinInt32 || int64(v) > math.MaxUint32 {
+		return IntArg{}, &ValidationError{Field: "int", Value: strconv.Itoa(v), Reason: "does not fit in 32 bits"}
+	}
+	return IntArg{Type: BOFArgTypeInt, Value: v}, nil
+}
+
+// NewShortArg returns a 16-bit integer argument. Values up to 65535 are
+// accepted for BOFs that read the short as unsigned.
+func NewShortArg(v int) (ShortArg, error) {
+	if v < math.MinInt16 || v > math.MaxUint16 {
+		return ShortArg{}, &ValidationError{Field: "short", Value: strconv.Itoa(v), Reason: "does not fit in 16 bits"}
+	}
+	return ShortArg{Type: BOFArgTypeShort, Value: v}, nil
+}
+
+// NewStringArg returns a null-terminated string argument in the target's ANSI code page
+func NewStringArg(s string) StringArg {
+	return StringArg{Type: BOFArgTypeString, Value: s}
+}
+
+// NewWStringArg returns a null-terminated UTF-16 string argument
+func NewWStringArg(s string) WStringArg {
+	return WStringArg{Type: BOFArgTypeWString, Value: s}
+}
+
+// NewBinaryArg returns a binary argument, base64-encoding data
+func NewBinaryArg(data []byte) BinaryArg {
+	return BinaryArg{Type: BOFArgTypeBinary, Value: base64.StdEncoding.EncodeToString(data)}
+}
+
+// validateBOFArguments checks hand-built arguments for the type names,
+// ranges and encodings the constructors guarantee
+func validateBOFArguments(args []BOFArgument) error {
+	for i, arg := range args {
+		var err error
+		switch a := arg.(type) {
+		case IntArg:
+			err = checkBOFArgType(a.Type, BOFArgTypeInt)
+			if err == nil {
+				_, err = NewIntArg(a.Value)
+			}
+		case ShortArg:
+			err = checkBOFArgType(a.Type, BOFArgTypeShort)
+			if err == nil {
+				_, err = NewShortArg(a.Value)
+			}
+		case StringArg:
+			err = checkBOFArgType(a.Type, BOFArgTypeString)
+		case WStringArg:
+			err = checkBOFArgType(a.Type, BOFArgTypeWString)
+		case BinaryArg:
+			err = checkBOFArgType(a.Type, BOFArgTypeBinary)
+			if err == nil {
+				if _, decodeErr := base64.StdEncoding.DecodeString(a.Value); decodeErr != nil {
+					err = &ValidationError{Field: "binary", Value: a.Value, Reason: "must be base64 encoded"}
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func checkBOFArgType(got, want string) error {
+	if got != want {
+		return &ValidationError{Field: "type", Value: got, Reason: fmt.Sprintf("must be %q", want)}
+	}
+	return nil
+}
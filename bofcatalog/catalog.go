@@ -0,0 +1,377 @@
+// Package bofcatalog loads a catalog of BOFs described by YAML (or JSON)
+// manifests and runs them by name with named arguments.
+//
+// Each BOF lives in its own directory with a bof.yaml manifest:
+//
+//	name: situational/whoami      # defaults to the directory path
+//	description: Current user, groups and privileges
+//	entrypoint: go                # defaults to "go"
+//	arch:
+//	  x64: whoami.x64.o
+//	  x86: whoami.x86.o
+//	arguments:
+//	  - name: target
+//	    type: wstring             # string, wstring, int, short or binary
+//	    required: true
+//	  - name: verbose
+//	    type: int
+//	    default: 0
+//	files:                        # auxiliary files attached to every run
+//	  - helper.bin
+//
+// Arguments are packed in manifest order. Paths are relative to the manifest.
+package bofcatalog
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	csclient "github.com/xenov-x/csrest"
+)
+
+// Manifest file names, in order of precedence within a directory
+var manifestNames = []string{"bof.yaml", "bof.yml", "bof.json"}
+
+// ErrUnknownBOF is returned for names that are not in the catalog
+var ErrUnknownBOF = errors.New("unknown BOF")
+
+// ArgSpec describes one BOF argument
+type ArgSpec struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"` // One of the csclient.BOFArgType* names
+	Required    bool        `json:"required,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+// Entry is a BOF described by a manifest
+type Entry struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Entrypoint  string            `json:"entrypoint,omitempty"`
+	Arch        map[string]string `json:"arch"` // Architecture -> object file
+	Arguments   []ArgSpec         `json:"arguments,omitempty"`
+	Files       []string          `json:"files,omitempty"` // Auxiliary files attached to every run
+
+	dir string // Manifest directory within the catalog file system
+}
+
+// Catalog is a set of BOFs loaded from a file system
+type Catalog struct {
+	client  *csclient.Client
+	fsys    fs.FS
+	entries map[string]*Entry
+}
+
+// Open loads every manifest in fsys (e.g. an embed.FS). client runs the BOFs
+// and may be nil if the catalog is only used to build requests.
+func Open(client *csclient.Client, fsys fs.FS) (*Catalog, error) {
+	c := &Catalog{client: client, fsys: fsys, entries: make(map[string]*Entry)}
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		for _, name := range manifestNames {
+			manifest := path.Join(p, name)
+			if _, err := fs.Stat(fsys, manifest); err != nil {
+				continue
+			}
+			entry, err := loadManifest(fsys, manifest)
+			if err != nil {
+				return fmt.Errorf("%s: %w", manifest, err)
+			}
+			if other, dup := c.entries[entry.Name]; dup {
+				return fmt.Errorf("%s: BOF %q is already defined in %s", manifest, entry.Name, other.dir)
+			}
+			c.entries[entry.Name] = entry
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BOF catalog: %w", err)
+	}
+	return c, nil
+}
+
+// OpenDir loads every manifest under dir
+func OpenDir(client *csclient.Client, dir string) (*Catalog, error) {
+	return Open(client, os.DirFS(dir))
+}
+
+func loadManifest(fsys fs.FS, manifest string) (*Entry, error) {
+	data, err := fs.ReadFile(fsys, manifest)
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if strings.HasSuffix(manifest, ".json") {
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, err
+		}
+	} else {
+		doc, err := parseYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		// Round-trip through JSON to map the generic document onto Entry
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(encoded, &entry); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.dir = path.Dir(manifest)
+	if entry.Name == "" {
+		if entry.dir == "." {
+			return nil, errors.New("a manifest at the catalog root must set name")
+		}
+		entry.Name = entry.dir
+	}
+	if entry.Entrypoint == "" {
+		entry.Entrypoint = "go"
+	}
+	if len(entry.Arch) == 0 {
+		return nil, errors.New("arch must list at least one object file")
+	}
+	for arch, file := range entry.Arch {
+		if arch != "x86" && arch != "x64" {
+			return nil, fmt.Errorf("arch %q must be x86 or x64", arch)
+		}
+		if _, err := fs.Stat(fsys, path.Join(entry.dir, file)); err != nil {
+			return nil, fmt.Errorf("arch %s: %w", arch, err)
+		}
+	}
+	for _, file := range entry.Files {
+		if _, err := fs.Stat(fsys, path.Join(entry.dir, file)); err != nil {
+			return nil, err
+		}
+	}
+	seen := make(map[string]bool)
+	for _, arg := range entry.Arguments {
+		if arg.Name == "" || seen[arg.Name] {
+			return nil, fmt.Errorf("argument names must be unique and non-empty (got %q)", arg.Name)
+		}
+		seen[arg.Name] = true
+		switch arg.Type {
+		case csclient.BOFArgTypeString, csclient.BOFArgTypeWString, csclient.BOFArgTypeInt, csclient.BOFArgTypeShort, csclient.BOFArgTypeBinary:
+		default:
+			return nil, fmt.Errorf("argument %s: unknown type %q", arg.Name, arg.Type)
+		}
+	}
+	return &entry, nil
+}
+
+// Names returns the names of every BOF in the catalog, sorted
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.entries))
+	for name := range c.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Entry returns the manifest of a BOF
+func (c *Catalog) Entry(name string) (*Entry, bool) {
+	e, ok := c.entries[name]
+	return e, ok
+}
+
+// RunBOF runs a catalog BOF on the beacon, choosing the object file for the
+// beacon's architecture and packing args by the manifest's argument schema
+func (c *Catalog) RunBOF(ctx context.Context, bid, name string, args map[string]interface{}) (*csclient.AsyncCommandResponse, error) {
+	req, err := c.beaconRequest(ctx, bid, name, args)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.ExecuteBOFPack(ctx, bid, req)
+}
+
+// RunBOFAndWait runs a catalog BOF and waits for the task to finish. A
+// non-positive timeout uses the client's task wait timeout.
+func (c *Catalog) RunBOFAndWait(ctx context.Context, bid, name string, args map[string]interface{}, timeout time.Duration) (*csclient.TaskDetailDto, error) {
+	req, err := c.beaconRequest(ctx, bid, name, args)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.ExecuteBOFAndWait(ctx, bid, req, timeout)
+}
+
+// beaconRequest builds the request for the beacon's architecture
+func (c *Catalog) beaconRequest(ctx context.Context, bid, name string, args map[string]interface{}) (csclient.InlineExecutePackDto, error) {
+	if c.client == nil {
+		return csclient.InlineExecutePackDto{}, fmt.Errorf("failed to run BOF %s: catalog has no client", name)
+	}
+	if _, ok := c.entries[name]; !ok {
+		return csclient.InlineExecutePackDto{}, fmt.Errorf("failed to run BOF %s: %w", name, ErrUnknownBOF)
+	}
+	beacon, err := c.client.GetBeacon(ctx, bid)
+	if err != nil {
+		return csclient.InlineExecutePackDto{}, fmt.Errorf("failed to run BOF %s: %w", name, err)
+	}
+	return c.Request(name, beacon.BeaconArch, args)
+}
+
+// Request builds the typed-argument request for a catalog BOF and architecture
+// without submitting it. The object file is checked with csclient.ValidateBOF.
+func (c *Catalog) Request(name, arch string, args map[string]interface{}) (csclient.InlineExecutePackDto, error) {
+	var req csclient.InlineExecutePackDto
+	e, ok := c.entries[name]
+	if !ok {
+		return req, fmt.Errorf("failed to build BOF %s: %w", name, ErrUnknownBOF)
+	}
+	file, ok := e.Arch[strings.ToLower(arch)]
+	if !ok {
+		return req, fmt.Errorf("failed to build BOF %s: no %s object file", name, arch)
+	}
+	packed, err := e.Pack(args)
+	if err != nil {
+		return req, fmt.Errorf("failed to build BOF %s: %w", name, err)
+	}
+
+	data, err := fs.ReadFile(c.fsys, path.Join(e.dir, file))
+	if err != nil {
+		return req, fmt.Errorf("failed to build BOF %s: %w", name, err)
+	}
+	if _, err := csclient.ValidateBOF(data, e.Entrypoint); err != nil {
+		return req, fmt.Errorf("failed to build BOF %s: %w", name, err)
+	}
+	key := csclient.FilesKey(file)
+	req.Files = map[string]string{key: base64.StdEncoding.EncodeToString(data)}
+	for _, aux := range e.Files {
+		auxKey := csclient.FilesKey(aux)
+		if _, dup := req.Files[auxKey]; dup {
+			return req, fmt.Errorf("failed to build BOF %s: auxiliary file %s has the same files key %q as another file", name, aux, auxKey)
+		}
+		auxData, err := fs.ReadFile(c.fsys, path.Join(e.dir, aux))
+		if err != nil {
+			return req, fmt.Errorf("failed to build BOF %s: %w", name, err)
+		}
+		req.Files[auxKey] = base64.StdEncoding.EncodeToString(auxData)
+	}
+	req.BOF = "@files/" + key
+	req.Entrypoint = e.Entrypoint
+	req.Arguments = packed
+	return req, nil
+}
+
+// Pack converts named arguments into typed BOF arguments in manifest order,
+// applying defaults. Unknown names and missing required arguments are errors.
+func (e *Entry) Pack(args map[string]interface{}) ([]csclient.BOFArgument, error) {
+	known := make(map[string]bool, len(e.Arguments))
+	for _, spec := range e.Arguments {
+		known[spec.Name] = true
+	}
+	for name := range args {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+	}
+
+	var packed []csclient.BOFArgument
+	for _, spec := range e.Arguments {
+		value, ok := args[spec.Name]
+		if !ok || value == nil {
+			if spec.Required {
+				return nil, fmt.Errorf("missing required argument %q", spec.Name)
+			}
+			value = spec.Default
+		}
+		arg, err := packArg(spec, value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", spec.Name, err)
+		}
+		packed = append(packed, arg)
+	}
+	return packed, nil
+}
+
+func packArg(spec ArgSpec, value interface{}) (csclient.BOFArgument, error) {
+	switch spec.Type {
+	case csclient.BOFArgTypeInt, csclient.BOFArgTypeShort:
+		n, err := toInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Type == csclient.BOFArgTypeShort {
+			return csclient.NewShortArg(n)
+		}
+		return csclient.NewIntArg(n)
+	case csclient.BOFArgTypeBinary:
+		switch v := value.(type) {
+		case nil:
+			return csclient.NewBinaryArg(nil), nil
+		case []byte:
+			return csclient.NewBinaryArg(v), nil
+		case string:
+			return csclient.NewBinaryArg([]byte(v)), nil
+		}
+		return nil, fmt.Errorf("cannot use %T as binary", value)
+	case csclient.BOFArgTypeWString:
+		return csclient.NewWStringArg(toString(value)), nil
+	default:
+		return csclient.NewStringArg(toString(value)), nil
+	}
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case uint16:
+		return int(v), nil
+	case uint32:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		n, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", v)
+		}
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("cannot use %T as an integer", value)
+}
+
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
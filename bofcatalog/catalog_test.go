@@ -0,0 +1,196 @@
+package bofcatalog
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	csclient "github.com/xenov-x/csrest"
+)
+
+// docManifest is the manifest example from the package documentation
+const docManifest = `name: situational/whoami      # defaults to the directory path
+description: Current user, groups and privileges
+entrypoint: go                # defaults to "go"
+arch:
+  x64: whoami.x64.o
+  x86: whoami.x86.o
+arguments:
+  - name: target
+    type: wstring             # string, wstring, int, short or binary
+    required: true
+  - name: verbose
+    type: int
+    default: 0
+files:                        # auxiliary files attached to every run
+  - helper.bin
+`
+
+// coffObject returns a minimal COFF object for machine defining the external symbol entry
+func coffObject(machine uint16, entry string) []byte {
+	const symtab = 20 + 40
+	data := make([]byte, symtab+18+4)
+	binary.LittleEndian.PutUint16(data[0:], machine)
+	binary.LittleEndian.PutUint16(data[2:], 1)
+	binary.LittleEndian.PutUint32(data[8:], symtab)
+	binary.LittleEndian.PutUint32(data[12:], 1)
+	copy(data[20:], ".text")
+	copy(data[symtab:], entry)
+	binary.LittleEndian.PutUint16(data[symtab+12:], 1)
+	data[symtab+16] = 2 // IMAGE_SYM_CLASS_EXTERNAL
+	binary.LittleEndian.PutUint32(data[symtab+18:], 4)
+	return data
+}
+
+func testCatalogFS() fstest.MapFS {
+	return fstest.MapFS{
+		"whoami/bof.yaml":     {Data: []byte(docManifest)},
+		"whoami/whoami.x64.o": {Data: coffObject(0x8664, "go")},
+		"whoami/whoami.x86.o": {Data: coffObject(0x014c, "_go")},
+		"whoami/helper.bin":   {Data: []byte("helper")},
+		"net/ipconfig/bof.json": {Data: []byte(`{
+			"description": "Adapters",
+			"arch": {"x64": "ipconfig.o"}
+		}`)},
+		"net/ipconfig/ipconfig.o": {Data: coffObject(0x8664, "go")},
+	}
+}
+
+func TestOpenDocManifest(t *testing.T) {
+	cat, err := Open(nil, testCatalogFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := cat.Names(); !reflect.DeepEqual(names, []string{"net/ipconfig", "situational/whoami"}) {
+		t.Fatalf("names = %v", names)
+	}
+	entry, ok := cat.Entry("situational/whoami")
+	if !ok {
+		t.Fatal("situational/whoami not found")
+	}
+	want := &Entry{
+		Name:        "situational/whoami",
+		Description: "Current user, groups and privileges",
+		Entrypoint:  "go",
+		Arch:        map[string]string{"x64": "whoami.x64.o", "x86": "whoami.x86.o"},
+		Arguments: []ArgSpec{
+			{Name: "target", Type: csclient.BOFArgTypeWString, Required: true},
+			{Name: "verbose", Type: csclient.BOFArgTypeInt, Default: float64(0)},
+		},
+		Files: []string{"helper.bin"},
+		dir:   "whoami",
+	}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("got  %+v\nwant %+v", entry, want)
+	}
+	if entry, _ := cat.Entry("net/ipconfig"); entry.Entrypoint != "go" || entry.Description != "Adapters" {
+		t.Errorf("JSON manifest: %+v", entry)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	cat, err := Open(nil, testCatalogFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := cat.Request("situational/whoami", "X86", map[string]interface{}{"target": "dc01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.BOF != "@files/whoami.x86.o" || req.Entrypoint != "go" {
+		t.Errorf("BOF %q entrypoint %q", req.BOF, req.Entrypoint)
+	}
+	if got := req.Files["helper.bin"]; got != base64.StdEncoding.EncodeToString([]byte("helper")) {
+		t.Errorf("helper.bin = %q", got)
+	}
+	wantArgs := []csclient.BOFArgument{csclient.NewWStringArg("dc01"), csclient.IntArg{Type: csclient.BOFArgTypeInt, Value: 0}}
+	if !reflect.DeepEqual(req.Arguments, wantArgs) {
+		t.Errorf("arguments = %+v", req.Arguments)
+	}
+
+	tests := []struct {
+		name string
+		bof  string
+		arch string
+		args map[string]interface{}
+	}{
+		{"unknown BOF", "missing", "x64", nil},
+		{"missing arch", "net/ipconfig", "x86", nil},
+		{"missing required argument", "situational/whoami", "x64", nil},
+		{"unknown argument", "situational/whoami", "x64", map[string]interface{}{"target": "a", "other": 1}},
+		{"bad integer", "situational/whoami", "x64", map[string]interface{}{"target": "a", "verbose": "yes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cat.Request(tt.bof, tt.arch, tt.args); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	if _, err := cat.Request("missing", "x64", nil); !errors.Is(err, ErrUnknownBOF) {
+		t.Errorf("got %v, want ErrUnknownBOF", err)
+	}
+}
+
+func TestRequestFilesKeyCollision(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dup/bof.yaml":      {Data: []byte("arch:\n  x64: dup.o\nfiles:\n  - data/dup.o\n")},
+		"dup/dup.o":         {Data: coffObject(0x8664, "go")},
+		"dup/data/dup.o":    {Data: []byte("aux")},
+		"dup2/bof.yaml":     {Data: []byte("arch:\n  x64: a.o\nfiles: [x/h.bin, y/h.bin]\n")},
+		"dup2/a.o":          {Data: coffObject(0x8664, "go")},
+		"dup2/x/h.bin":      {Data: []byte("x")},
+		"dup2/y/h.bin":      {Data: []byte("y")},
+		"valid/bof.yaml":    {Data: []byte("arch:\n  x64: valid.o\n")},
+		"valid/valid.o":     {Data: coffObject(0x8664, "go")},
+		"invalid/bof.yaml":  {Data: []byte("arch:\n  x64: invalid.o\nentrypoint: main\n")},
+		"invalid/invalid.o": {Data: coffObject(0x8664, "go")},
+	}
+	cat, err := Open(nil, fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dup", "dup2"} {
+		if _, err := cat.Request(name, "x64", nil); err == nil || !strings.Contains(err.Error(), "same files key") {
+			t.Errorf("%s: got %v, want a files key collision", name, err)
+		}
+	}
+	if _, err := cat.Request("valid", "x64", nil); err != nil {
+		t.Errorf("valid: %v", err)
+	}
+	if _, err := cat.Request("invalid", "x64", nil); !errors.Is(err, csclient.ErrInvalidCOFF) {
+		t.Errorf("invalid: got %v, want ErrInvalidCOFF", err)
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	obj := &fstest.MapFile{Data: coffObject(0x8664, "go")}
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"root manifest without a name", fstest.MapFS{"bof.yaml": {Data: []byte("arch:\n  x64: a.o\n")}, "a.o": obj}},
+		{"no arch", fstest.MapFS{"a/bof.yaml": {Data: []byte("name: a\n")}}},
+		{"unknown arch", fstest.MapFS{"a/bof.yaml": {Data: []byte("arch:\n  arm64: a.o\n")}, "a/a.o": obj}},
+		{"missing object file", fstest.MapFS{"a/bof.yaml": {Data: []byte("arch:\n  x64: a.o\n")}}},
+		{"missing auxiliary file", fstest.MapFS{"a/bof.yaml": {Data: []byte("arch:\n  x64: a.o\nfiles: [b.bin]\n")}, "a/a.o": obj}},
+		{"unknown argument type", fstest.MapFS{"a/bof.yaml": {Data: []byte("arch:\n  x64: a.o\narguments:\n  - name: x\n    type: long\n")}, "a/a.o": obj}},
+		{"duplicate argument", fstest.MapFS{"a/bof.yaml": {Data: []byte("arch:\n  x64: a.o\narguments:\n  - name: x\n    type: int\n  - name: x\n    type: int\n")}, "a/a.o": obj}},
+		{"duplicate name", fstest.MapFS{
+			"a/bof.yaml": {Data: []byte("name: x\narch:\n  x64: a.o\n")}, "a/a.o": obj,
+			"b/bof.yaml": {Data: []byte("name: x\narch:\n  x64: b.o\n")}, "b/b.o": obj,
+		}},
+		{"invalid YAML", fstest.MapFS{"a/bof.yaml": {Data: []byte("description: a: b\narch:\n  x64: a.o\n")}, "a/a.o": obj}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Open(nil, tt.fsys); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
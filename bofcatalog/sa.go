@@ -0,0 +1,118 @@
+package bofcatalog
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	csclient "github.com/xenov-x/csrest"
+)
+
+// saBOFs are the situational awareness BOFs wrapped by SA, as laid out in the
+// SA directory of TrustedSec's CS-Situational-Awareness-BOF release
+// (<name>/<name>.x64.o and <name>/<name>.x86.o). None of them take arguments.
+var saBOFs = []string{"whoami", "ipconfig", "netstat", "listdns", "env"}
+
+// SA runs common situational awareness BOFs and parses their output, so a
+// whoami or ipconfig does not need cmd.exe or a hand-packed BOF
+type SA struct {
+	catalog *Catalog
+}
+
+// OpenSA registers the situational awareness BOFs found in fsys, the compiled
+// SA directory of CS-Situational-Awareness-BOF. BOFs missing from fsys are
+// skipped; calling their helper returns an error wrapping ErrUnknownBOF.
+func OpenSA(client *csclient.Client, fsys fs.FS) (*SA, error) {
+	c := &Catalog{client: client, fsys: fsys, entries: make(map[string]*Entry)}
+	for _, name := range saBOFs {
+		entry := &Entry{
+			Name:       name,
+			Entrypoint: "go",
+			Arch:       make(map[string]string),
+			dir:        name,
+		}
+		for _, arch := range []string{"x64", "x86"} {
+			file := name + "." + arch + ".o"
+			if _, err := fs.Stat(fsys, path.Join(name, file)); err == nil {
+				entry.Arch[arch] = file
+			}
+		}
+		if len(entry.Arch) > 0 {
+			c.entries[name] = entry
+		}
+	}
+	if len(c.entries) == 0 {
+		return nil, fmt.Errorf("failed to load SA BOFs: none of %v found", saBOFs)
+	}
+	return &SA{catalog: c}, nil
+}
+
+// OpenSADir registers the situational awareness BOFs found under dir
+func OpenSADir(client *csclient.Client, dir string) (*SA, error) {
+	return OpenSA(client, os.DirFS(dir))
+}
+
+// Catalog returns the catalog holding the SA BOFs, named "whoami", "ipconfig", etc.
+func (s *SA) Catalog() *Catalog {
+	return s.catalog
+}
+
+// run executes an SA BOF, waits for it and returns its text output
+func (s *SA) run(ctx context.Context, bid, name string) (string, error) {
+	task, err := s.catalog.RunBOFAndWait(ctx, bid, name, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	if task.TaskStatus == csclient.TaskStatusFailed {
+		return "", fmt.Errorf("failed to run BOF %s: task %s failed", name, task.TaskID)
+	}
+	return csclient.TaskOutputText(task), nil
+}
+
+// Whoami returns the beacon's user, groups and privileges
+func (s *SA) Whoami(ctx context.Context, bid string) (*csclient.WhoamiInfo, error) {
+	output, err := s.run(ctx, bid, "whoami")
+	if err != nil {
+		return nil, err
+	}
+	info := csclient.ParseWhoamiAll(output)
+	return &info, nil
+}
+
+// IPConfig returns the network adapters of the beacon's host
+func (s *SA) IPConfig(ctx context.Context, bid string) ([]csclient.NetworkAdapter, error) {
+	output, err := s.run(ctx, bid, "ipconfig")
+	if err != nil {
+		return nil, err
+	}
+	return csclient.ParseIPConfig(output), nil
+}
+
+// Netstat returns the TCP and UDP sockets of the beacon's host
+func (s *SA) Netstat(ctx context.Context, bid string) ([]csclient.NetstatEntry, error) {
+	output, err := s.run(ctx, bid, "netstat")
+	if err != nil {
+		return nil, err
+	}
+	return csclient.ParseNetstat(output), nil
+}
+
+// ListDNS returns the DNS cache of the beacon's host
+func (s *SA) ListDNS(ctx context.Context, bid string) ([]csclient.DNSCacheEntry, error) {
+	output, err := s.run(ctx, bid, "listdns")
+	if err != nil {
+		return nil, err
+	}
+	return csclient.ParseDNSCache(output), nil
+}
+
+// Env returns the environment variables of the beacon process
+func (s *SA) Env(ctx context.Context, bid string) (map[string]string, error) {
+	output, err := s.run(ctx, bid, "env")
+	if err != nil {
+		return nil, err
+	}
+	return csclient.ParseEnvironment(output), nil
+}
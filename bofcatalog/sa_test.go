@@ -0,0 +1,38 @@
+package bofcatalog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOpenSA(t *testing.T) {
+	fsys := fstest.MapFS{
+		"whoami/whoami.x64.o":     {Data: coffObject(0x8664, "go")},
+		"whoami/whoami.x86.o":     {Data: coffObject(0x014c, "_go")},
+		"ipconfig/ipconfig.x64.o": {Data: coffObject(0x8664, "go")},
+		"unrelated/other.x64.o":   {Data: coffObject(0x8664, "go")},
+	}
+	sa, err := OpenSA(nil, fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := sa.Catalog().Names(); !reflect.DeepEqual(names, []string{"ipconfig", "whoami"}) {
+		t.Fatalf("names = %v", names)
+	}
+	req, err := sa.Catalog().Request("whoami", "x86", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.BOF != "@files/whoami.x86.o" || len(req.Arguments) != 0 {
+		t.Errorf("request = %+v", req)
+	}
+	if _, err := sa.Catalog().Request("netstat", "x64", nil); !errors.Is(err, ErrUnknownBOF) {
+		t.Errorf("got %v, want ErrUnknownBOF", err)
+	}
+
+	if _, err := OpenSA(nil, fstest.MapFS{"unrelated/other.x64.o": {Data: []byte{}}}); err == nil {
+		t.Error("expected an error when no SA BOF is present")
+	}
+}
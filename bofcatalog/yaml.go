@@ -0,0 +1,324 @@
+package bofcatalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a non-blank line of a YAML document with comments removed
+type yamlLine struct {
+	num    int // 1-based line number for errors
+	indent int
+	text   string
+}
+
+// parseYAML decodes the block-style YAML subset used by catalog manifests:
+// mappings, sequences (of scalars or mappings), flow sequences of scalars,
+// quoted and plain scalars, and comments. Anchors, multi-line scalars and
+// multiple documents are not supported.
+func parseYAML(data []byte) (interface{}, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := stripYAMLComment(raw)
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "---" {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(text, " "), "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		lines = append(lines, yamlLine{num: i + 1, indent: len(text) - len(trimmed), text: strings.TrimRight(trimmed, " \t")})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	p := &yamlParser{lines: lines}
+	value, err := p.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return value, nil
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// block parses the mapping or sequence starting at the current line
+func (p *yamlParser) block(indent int) (interface{}, error) {
+	if isYAMLSeqItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) mapping(indent int) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+		if isYAMLSeqItem(line.text) {
+			return nil, fmt.Errorf("line %d: sequence item where a mapping key was expected", line.num)
+		}
+		key, value, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		if _, dup := m[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+		p.pos++
+
+		if value != "" {
+			scalar, err := parseYAMLValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.num, err)
+			}
+			m[key] = scalar
+			continue
+		}
+		// A nested block is indented further, except that a sequence may
+		// start at the key's own indentation
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || next.indent == indent && isYAMLSeqItem(next.text) {
+				nested, err := p.block(next.indent)
+				if err != nil {
+					return nil, err
+				}
+				m[key] = nested
+				continue
+			}
+		}
+		m[key] = nil
+	}
+	return m, nil
+}
+
+func (p *yamlParser) sequence(indent int) ([]interface{}, error) {
+	var items []interface{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !isYAMLSeqItem(line.text) {
+			if line.indent > indent {
+				return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+			}
+			break
+		}
+		rest := strings.TrimPrefix(line.text, "-")
+		trimmed := strings.TrimLeft(rest, " ")
+		if trimmed == "" {
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				nested, err := p.block(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, nested)
+			} else {
+				items = append(items, nil)
+			}
+			continue
+		}
+		if _, _, ok := splitYAMLKey(trimmed); ok || isYAMLSeqItem(trimmed) {
+			// "- key: value" starts a mapping whose keys align with "key"
+			p.lines[p.pos] = yamlLine{num: line.num, indent: indent + 1 + len(rest) - len(trimmed), text: trimmed}
+			nested, err := p.block(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, nested)
+			continue
+		}
+		scalar, err := parseYAMLValue(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.num, err)
+		}
+		items = append(items, scalar)
+		p.pos++
+	}
+	return items, nil
+}
+
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits "key: value" (or "key:") outside of quotes
+func splitYAMLKey(text string) (key, value string, ok bool) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			if i == 0 {
+				quote = ch
+			}
+		case ch == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			key = strings.TrimSpace(text[:i])
+			if unquoted, err := parseYAMLScalar(key); err == nil {
+				if s, isString := unquoted.(string); isString {
+					key = s
+				}
+			}
+			return key, strings.TrimSpace(text[i+1:]), key != ""
+		}
+	}
+	return "", "", false
+}
+
+// stripYAMLComment removes a "#" comment that is not inside a quoted scalar.
+// Quotes only open a scalar at the start of a token, so apostrophes within
+// plain scalars ("the user's tokens") are ordinary characters.
+func stripYAMLComment(line string) string {
+	var quote byte
+	flow := 0
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++ // Skip the escaped character
+		case quote == '\'' && ch == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			i++ // '' is an escaped quote
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && yamlTokenStart(line[:i], flow > 0):
+			quote = ch
+		case ch == '[' && yamlTokenStart(line[:i], flow > 0):
+			flow++
+		case ch == ']' && flow > 0:
+			flow--
+		case ch == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlTokenStart reports whether a scalar following prefix starts a token: at
+// the start of the line, after a "- " or "key: " indicator or, within a flow
+// sequence, after "[" or ","
+func yamlTokenStart(prefix string, inFlow bool) bool {
+	trimmed := strings.TrimRight(prefix, " \t")
+	if trimmed == "" {
+		return true
+	}
+	spaced := len(trimmed) < len(prefix)
+	switch last := trimmed[len(trimmed)-1]; {
+	case inFlow && (last == '[' || last == ','):
+		return true
+	case last == ':':
+		return spaced
+	case last == '-':
+		return spaced && yamlTokenStart(trimmed[:len(trimmed)-1], false)
+	}
+	return false
+}
+
+// parseYAMLValue parses a scalar or a flow sequence of scalars
+func parseYAMLValue(text string) (interface{}, error) {
+	if !strings.HasPrefix(text, "[") {
+		return parseYAMLScalar(text)
+	}
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unterminated flow sequence %q", text)
+	}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	items := []interface{}{}
+	if inner == "" {
+		return items, nil
+	}
+	for _, part := range splitYAMLFlow(inner) {
+		item, err := parseYAMLScalar(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// splitYAMLFlow splits flow sequence items on commas outside of quotes
+func splitYAMLFlow(text string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote == '"' && ch == '\\':
+			i++
+		case quote == '\'' && ch == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && strings.TrimSpace(text[start:i]) == "":
+			quote = ch
+		case ch == ',':
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// parseYAMLScalar converts a scalar to a string, bool, int64, float64 or nil
+func parseYAMLScalar(text string) (interface{}, error) {
+	if text == "" {
+		return "", nil
+	}
+	switch text[0] {
+	case '"':
+		if len(text) < 2 || text[len(text)-1] != '"' {
+			return nil, fmt.Errorf("unterminated string %s", text)
+		}
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s: %w", text, err)
+		}
+		return s, nil
+	case '\'':
+		if len(text) < 2 || text[len(text)-1] != '\'' {
+			return nil, fmt.Errorf("unterminated string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case '{', '&', '*', '|', '>', '!':
+		return nil, fmt.Errorf("unsupported YAML syntax %q", text)
+	}
+	switch text {
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case "null", "Null", "NULL", "~":
+		return nil, nil
+	}
+	if strings.Contains(text, ": ") || strings.HasSuffix(text, ":") {
+		return nil, fmt.Errorf("plain scalar %q contains \": \"; quote it", text)
+	}
+	if n, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f, nil
+	}
+	return text, nil
+}
@@ -0,0 +1,101 @@
+package bofcatalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want interface{}
+	}{
+		{
+			name: "apostrophe in a plain scalar",
+			doc:  "description: Dump the user's tokens # note\n",
+			want: map[string]interface{}{"description": "Dump the user's tokens"},
+		},
+		{
+			name: "hash inside quotes",
+			doc:  "a: 'x # y' # comment\nb: \"it's # here\"\nc: 'it''s # here'\nd: \"say \\\"hi\\\" # x\"\n",
+			want: map[string]interface{}{"a": "x # y", "b": "it's # here", "c": "it's # here", "d": `say "hi" # x`},
+		},
+		{
+			name: "hash without a preceding space",
+			doc:  "url: http://host/#frag\n",
+			want: map[string]interface{}{"url": "http://host/#frag"},
+		},
+		{
+			name: "scalars",
+			doc:  "s: text\nn: 42\nh: 0x10\nf: 1.5\nb: true\nz: ~\ne:\n",
+			want: map[string]interface{}{"s": "text", "n": int64(42), "h": int64(16), "f": 1.5, "b": true, "z": nil, "e": nil},
+		},
+		{
+			name: "flow sequences",
+			doc:  "a: [x64, x86]\nb: []\nc: ['a, b', user's, \"q\"] # comment\n",
+			want: map[string]interface{}{
+				"a": []interface{}{"x64", "x86"},
+				"b": []interface{}{},
+				"c": []interface{}{"a, b", "user's", "q"},
+			},
+		},
+		{
+			name: "sequence at the key indentation",
+			doc:  "files:\n- a.bin\n- 'b # c.bin'\nafter: 1\n",
+			want: map[string]interface{}{"files": []interface{}{"a.bin", "b # c.bin"}, "after": int64(1)},
+		},
+		{
+			name: "sequence of mappings",
+			doc:  "args:\n  - name: a\n    type: int\n  -\n    name: b\n",
+			want: map[string]interface{}{"args": []interface{}{
+				map[string]interface{}{"name": "a", "type": "int"},
+				map[string]interface{}{"name": "b"},
+			}},
+		},
+		{
+			name: "CRLF and document marker",
+			doc:  "---\r\nname: x\r\n",
+			want: map[string]interface{}{"name": "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML([]byte(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"nested mapping value", "a: b: c\n"},
+		{"nested mapping value in a sequence", "- a: b: c\n"},
+		{"trailing colon in a value", "a: b:\n"},
+		{"duplicate key", "a: 1\na: 2\n"},
+		{"tab indentation", "a:\n\tb: 1\n"},
+		{"unexpected indentation", "a: 1\n  b: 2\n"},
+		{"sequence item in a mapping", "a: 1\n- b\n"},
+		{"missing colon", "a: 1\nb\n"},
+		{"unterminated string", "a: 'x\n"},
+		{"unterminated flow sequence", "a: [x, y\n"},
+		{"anchor", "a: &anchor x\n"},
+		{"block scalar", "a: |\n  text\n"},
+		{"flow mapping", "a: {b: c}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := parseYAML([]byte(tt.doc)); err == nil {
+				t.Errorf("parsed %q as %#v, want an error", tt.doc, got)
+			}
+		})
+	}
+}
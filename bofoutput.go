@@ -0,0 +1,153 @@
+package csclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BOFCallbackType is the beacon output callback a BOF reported data with
+// (the first argument of BeaconPrintf and BeaconOutput)
+type BOFCallbackType int
+
+// Beacon output callback types. BOFs may also use custom types in the range
+// BOFCallbackCustom to BOFCallbackCustomLast for data handled by a script.
+const (
+	BOFCallbackOutput     BOFCallbackType = 0x00
+	BOFCallbackError      BOFCallbackType = 0x0d
+	BOFCallbackOutputOEM  BOFCallbackType = 0x1e
+	BOFCallbackOutputUTF8 BOFCallbackType = 0x20
+	BOFCallbackCustom     BOFCallbackType = 0x1000
+	BOFCallbackCustomLast BOFCallbackType = 0x13ff
+)
+
+var bofCallbackNames = map[BOFCallbackType]string{
+	BOFCallbackOutput:     "OUTPUT",
+	BOFCallbackError:      "ERROR",
+	BOFCallbackOutputOEM:  "OUTPUT_OEM",
+	BOFCallbackOutputUTF8: "OUTPUT_UTF8",
+}
+
+// String returns the callback name without its CALLBACK_ prefix, e.g. "ERROR" or "CUSTOM_0x1001"
+func (t BOFCallbackType) String() string {
+	if name, ok := bofCallbackNames[t]; ok {
+		return name
+	}
+	if t.IsCustom() {
+		return fmt.Sprintf("CUSTOM_%#x", int(t))
+	}
+	return fmt.Sprintf("UNKNOWN_%#x", int(t))
+}
+
+// IsCustom reports whether the type is in the custom callback range
+func (t BOFCallbackType) IsCustom() bool {
+	return t >= BOFCallbackCustom && t <= BOFCallbackCustomLast
+}
+
+// IsOutput reports whether the type carries regular text output
+func (t BOFCallbackType) IsOutput() bool {
+	return t == BOFCallbackOutput || t == BOFCallbackOutputOEM || t == BOFCallbackOutputUTF8
+}
+
+// BOFOutput is one output callback of a BOF
+type BOFOutput struct {
+	CallbackType BOFCallbackType
+	Data         []byte
+	Timestamp    time.Time
+}
+
+// Text returns the data as a string
+func (o BOFOutput) Text() string {
+	return string(o.Data)
+}
+
+// Console prefixes the team server puts in front of BOF output and errors
+const (
+	bofOutputPrefix = "received output:"
+	bofErrorPrefix  = "[-]"
+)
+
+// DecodeBOFOutput classifies and decodes the result entries of a BOF task.
+// Entries carrying a callback type ("callbackType", "callback_type" or
+// "callback", as a number or a name such as "CALLBACK_ERROR") are decoded
+// with it, taking binary data from a base64 "data" field when present. Plain
+// text entries are classified by their console prefix: "[-]" marks an error
+// and anything else is output. Entries of other output types (ls, ps...) are skipped.
+func DecodeBOFOutput(task *TaskDetailDto) ([]BOFOutput, error) {
+	var outputs []BOFOutput
+	for i, entry := range task.Result {
+		out := BOFOutput{}
+		if ts, ok := entry["timestamp"].(string); ok {
+			out.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		}
+
+		callback, hasCallback, err := bofEntryCallback(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode result %d: %w", i, err)
+		}
+		if hasCallback {
+			out.CallbackType = callback
+			if data, ok := entry["data"].(string); ok {
+				if out.Data, err = base64.StdEncoding.DecodeString(data); err != nil {
+					return nil, fmt.Errorf("failed to decode result %d data: %w", i, err)
+				}
+			} else if text, ok := entry["output"].(string); ok {
+				out.Data = []byte(text)
+			}
+			outputs = append(outputs, out)
+			continue
+		}
+
+		if ResultOutputType(entry) != OutputTypeText {
+			continue
+		}
+		text, _ := entry["output"].(string)
+		trimmed := strings.TrimLeft(text, " \t\r\n")
+		switch {
+		case strings.HasPrefix(trimmed, bofErrorPrefix):
+			out.CallbackType = BOFCallbackError
+			text = strings.TrimSpace(strings.TrimPrefix(trimmed, bofErrorPrefix))
+		case strings.HasPrefix(trimmed, bofOutputPrefix):
+			out.CallbackType = BOFCallbackOutput
+			text = strings.TrimLeft(strings.TrimPrefix(trimmed, bofOutputPrefix), "\r\n")
+		default:
+			out.CallbackType = BOFCallbackOutput
+		}
+		out.Data = []byte(text)
+		outputs = append(outputs, out)
+	}
+	return outputs, nil
+}
+
+// bofEntryCallback reads the callback type of a result entry, if it has one
+func bofEntryCallback(entry map[string]interface{}) (BOFCallbackType, bool, error) {
+	for _, key := range []string{"callbackType", "callback_type", "callback"} {
+		switch v := entry[key].(type) {
+		case float64:
+			return BOFCallbackType(v), true, nil
+		case string:
+			t, err := parseBOFCallbackType(v)
+			return t, true, err
+		}
+	}
+	return 0, false, nil
+}
+
+// parseBOFCallbackType parses a callback name ("ERROR", "CALLBACK_OUTPUT",
+// "CUSTOM_0x1001") or number ("13", "0x1001")
+func parseBOFCallbackType(s string) (BOFCallbackType, error) {
+	name := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "CALLBACK_")
+	for t, n := range bofCallbackNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "CUSTOM_"), "UNKNOWN_")
+	n, err := strconv.ParseInt(strings.ToLower(name), 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown callback type %q", s)
+	}
+	return BOFCallbackType(n), nil
+}
@@ -0,0 +1,136 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// taskCallbacks holds the handlers registered with OnTaskComplete and OnTaskFailed
+type taskCallbacks struct {
+	mu        sync.RWMutex
+	completed []func(TaskDetailDto)
+	failed    []func(TaskDetailDto)
+	onError   func(error)
+}
+
+// OnTaskComplete registers a handler called once when a task reaches COMPLETED.
+// Intermediate OUTPUT_RECEIVED updates do not trigger it.
+func (c *Client) OnTaskComplete(fn func(TaskDetailDto)) {
+	c.callbacks.mu.Lock()
+	defer c.callbacks.mu.Unlock()
+	c.callbacks.completed = append(c.callbacks.completed, fn)
+}
+
+// OnTaskFailed registers a handler called once when a task reaches FAILED
+func (c *Client) OnTaskFailed(fn func(TaskDetailDto)) {
+	c.callbacks.mu.Lock()
+	defer c.callbacks.mu.Unlock()
+	c.callbacks.failed = append(c.callbacks.failed, fn)
+}
+
+// OnCallbackError registers a handler for polling errors and panics recovered from task handlers
+func (c *Client) OnCallbackError(fn func(error)) {
+	c.callbacks.mu.Lock()
+	defer c.callbacks.mu.Unlock()
+	c.callbacks.onError = fn
+}
+
+// ServeTaskCallbacks runs the background watcher that dispatches task handlers
+// until ctx ends. Each handler runs in isolation: a panicking handler is
+// recovered, reported to the OnCallbackError handler and does not stop the watcher.
+func (c *Client) ServeTaskCallbacks(ctx context.Context, opts ...SubscribeOption) error {
+	events, err := c.Subscribe(ctx, []EventTopic{TopicTaskStatus}, opts...)
+	if err != nil {
+		return err
+	}
+	dispatched := newRecentSet(dispatchedTaskLimit)
+
+	for event := range events {
+		if event.Topic == TopicError {
+			c.reportCallbackError(event.Err)
+			continue
+		}
+		status := event.Task.TaskStatus
+		if status != TaskStatusCompleted && status != TaskStatusFailed {
+			continue
+		}
+		if dispatched.has(event.Task.TaskID) {
+			continue
+		}
+
+		task, err := c.GetTask(ctx, event.Task.TaskID)
+		if err != nil {
+			c.reportCallbackError(err)
+			continue
+		}
+		dispatched.add(task.TaskID)
+
+		c.callbacks.mu.RLock()
+		handlers := c.callbacks.completed
+		if task.TaskStatus == TaskStatusFailed {
+			handlers = c.callbacks.failed
+		}
+		handlers = append([]func(TaskDetailDto){}, handlers...)
+		c.callbacks.mu.RUnlock()
+
+		for _, handler := range handlers {
+			c.runCallback(handler, *task)
+		}
+	}
+	return ctx.Err()
+}
+
+// dispatchedTaskLimit bounds the task IDs ServeTaskCallbacks remembers to
+// avoid dispatching a task twice
+const dispatchedTaskLimit = 4096
+
+// recentSet remembers the most recently added keys, forgetting the oldest
+// once it holds limit keys
+type recentSet struct {
+	limit int
+	keys  map[string]bool
+	order []string
+}
+
+func newRecentSet(limit int) *recentSet {
+	return &recentSet{limit: limit, keys: make(map[string]bool)}
+}
+
+// has reports whether key is remembered
+func (s *recentSet) has(key string) bool {
+	return s.keys[key]
+}
+
+// add records key
+func (s *recentSet) add(key string) {
+	if s.keys[key] {
+		return
+	}
+	if len(s.order) >= s.limit {
+		delete(s.keys, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.keys[key] = true
+	s.order = append(s.order, key)
+}
+
+// runCallback calls a handler, recovering any panic
+func (c *Client) runCallback(handler func(TaskDetailDto), task TaskDetailDto) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.reportCallbackError(fmt.Errorf("task callback panicked for task %s: %v", task.TaskID, r))
+		}
+	}()
+	handler(task)
+}
+
+// reportCallbackError passes err to the registered error handler, if any
+func (c *Client) reportCallbackError(err error) {
+	c.callbacks.mu.RLock()
+	onError := c.callbacks.onError
+	c.callbacks.mu.RUnlock()
+	if onError != nil {
+		onError(err)
+	}
+}
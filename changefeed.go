@@ -0,0 +1,142 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Change sources published by client-side subsystems
+const (
+	ChangeSourceOnceGuard = "once"
+	ChangeSourceTags      = "tags"
+)
+
+// StateChange is a single change to client-side state
+type StateChange struct {
+	Seq    uint64      `json:"seq"`    // Monotonic sequence number, starting at 1
+	Time   time.Time   `json:"time"`   // When the change was published
+	Source string      `json:"source"` // Subsystem that produced the change (see ChangeSource constants)
+	Kind   string      `json:"kind"`   // Subsystem specific change kind, e.g. "mark"
+	Key    string      `json:"key"`    // Identifier of the changed item
+	Data   interface{} `json:"data,omitempty"`
+}
+
+// StateChangeFeed aggregates changes from client-side subsystems into a single
+// ordered stream, so external UIs can mirror state incrementally instead of re-polling it
+type StateChangeFeed struct {
+	mu      sync.Mutex
+	seq     uint64
+	history []StateChange
+	limit   int
+	subs    map[chan StateChange]struct{}
+}
+
+// subscriberBuffer is the number of changes buffered per subscriber before it is dropped
+const subscriberBuffer = 256
+
+// NewStateChangeFeed creates a feed retaining the last historySize changes for replay
+func NewStateChangeFeed(historySize int) *StateChangeFeed {
+	if historySize < 1 {
+		historySize = 1
+	}
+	return &StateChangeFeed{
+		limit: historySize,
+		subs:  make(map[chan StateChange]struct{}),
+	}
+}
+
+// Publish appends a change to the feed and delivers it to subscribers.
+// Subscribers that cannot keep up are dropped and their channel closed;
+// they should resubscribe from the last sequence number they processed.
+func (f *StateChangeFeed) Publish(source, kind, key string, data interface{}) StateChange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	change := StateChange{
+		Seq:    f.seq,
+		Time:   time.Now(),
+		Source: source,
+		Kind:   kind,
+		Key:    key,
+		Data:   data,
+	}
+
+	f.history = append(f.history, change)
+	if len(f.history) > f.limit {
+		f.history = append(f.history[:0:0], f.history[len(f.history)-f.limit:]...)
+	}
+
+	for ch := range f.subs {
+		select {
+		case ch <- change:
+		default:
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+	return change
+}
+
+// Seq returns the sequence number of the latest change
+func (f *StateChangeFeed) Seq() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seq
+}
+
+// Since returns the retained changes with a sequence number greater than seq.
+// complete is false when older changes were already discarded, in which case
+// the consumer must rebuild its state from scratch.
+func (f *StateChangeFeed) Since(seq uint64) (changes []StateChange, complete bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sinceLocked(seq)
+}
+
+func (f *StateChangeFeed) sinceLocked(seq uint64) ([]StateChange, bool) {
+	complete := len(f.history) == 0 || f.history[0].Seq <= seq+1
+	var changes []StateChange
+	for _, change := range f.history {
+		if change.Seq > seq {
+			changes = append(changes, change)
+		}
+	}
+	return changes, complete
+}
+
+// Subscribe replays retained changes after seq and then delivers new changes
+// until ctx ends. Use 0 to receive every retained change.
+func (f *StateChangeFeed) Subscribe(ctx context.Context, seq uint64) (<-chan StateChange, error) {
+	f.mu.Lock()
+	backlog, complete := f.sinceLocked(seq)
+	if !complete {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("changes after sequence %d are no longer retained", seq)
+	}
+	if len(backlog) > subscriberBuffer {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("%d changes to replay exceeds subscriber buffer", len(backlog))
+	}
+
+	ch := make(chan StateChange, subscriberBuffer)
+	for _, change := range backlog {
+		ch <- change
+	}
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subs[ch]; ok {
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}()
+
+	return ch, nil
+}
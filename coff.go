@@ -0,0 +1,171 @@
+package csclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidCOFF is returned when a BOF is not a well-formed COFF object file
+var ErrInvalidCOFF = errors.New("invalid COFF object")
+
+// COFF layout constants
+const (
+	coffHeaderSize        = 20
+	coffSectionHeaderSize = 40
+	coffSymbolSize        = 18
+	coffSymClassExternal  = 2
+	coffSectionBSS        = 0x80 // IMAGE_SCN_CNT_UNINITIALIZED_DATA
+)
+
+// COFFSymbol is an external symbol of a COFF object
+type COFFSymbol struct {
+	Name    string
+	Section int // 1-based section number; 0 for imports resolved by the beacon
+}
+
+// COFFFile summarizes a COFF object file (BOF)
+type COFFFile struct {
+	Arch     string // x86 or x64
+	Sections []string
+	Symbols  []COFFSymbol // External symbols, defined and imported
+}
+
+// ParseCOFF parses the headers, section table and symbol table of a COFF
+// object file, checking that every table lies within data
+func ParseCOFF(data []byte) (*COFFFile, error) {
+	if len(data) >= 2 && data[0] == 'M' && data[1] == 'Z' {
+		return nil, fmt.Errorf("%w: file is a PE image (DLL/EXE), not an object file", ErrInvalidCOFF)
+	}
+	if len(data) < coffHeaderSize {
+		return nil, fmt.Errorf("%w: %d bytes is shorter than the file header", ErrInvalidCOFF, len(data))
+	}
+
+	f := &COFFFile{}
+	switch machine := binary.LittleEndian.Uint16(data); machine {
+	case machineI386:
+		f.Arch = "x86"
+	case machineAMD64:
+		f.Arch = "x64"
+	default:
+		return nil, fmt.Errorf("%w: unsupported machine type 0x%04x", ErrInvalidCOFF, machine)
+	}
+	numSections := int(binary.LittleEndian.Uint16(data[2:]))
+	symtabOffset := int(binary.LittleEndian.Uint32(data[8:]))
+	numSymbols := int(binary.LittleEndian.Uint32(data[12:]))
+	if optional := binary.LittleEndian.Uint16(data[16:]); optional != 0 {
+		return nil, fmt.Errorf("%w: object files have no optional header (size %d)", ErrInvalidCOFF, optional)
+	}
+
+	sectionsEnd := coffHeaderSize + numSections*coffSectionHeaderSize
+	if sectionsEnd > len(data) {
+		return nil, fmt.Errorf("%w: section table of %d sections is truncated", ErrInvalidCOFF, numSections)
+	}
+	for i := 0; i < numSections; i++ {
+		hdr := data[coffHeaderSize+i*coffSectionHeaderSize:]
+		name := string(bytes.TrimRight(hdr[:8], "\x00"))
+		rawSize := int64(binary.LittleEndian.Uint32(hdr[16:]))
+		rawOffset := int64(binary.LittleEndian.Uint32(hdr[20:]))
+		flags := binary.LittleEndian.Uint32(hdr[36:])
+		if flags&coffSectionBSS == 0 && rawSize > 0 && rawOffset+rawSize > int64(len(data)) {
+			return nil, fmt.Errorf("%w: section %s data is truncated", ErrInvalidCOFF, name)
+		}
+		f.Sections = append(f.Sections, name)
+	}
+
+	if numSymbols == 0 {
+		return f, nil
+	}
+	symtabEnd := int64(symtabOffset) + int64(numSymbols)*coffSymbolSize
+	if symtabOffset < sectionsEnd || symtabEnd+4 > int64(len(data)) {
+		return nil, fmt.Errorf("%w: symbol table is truncated", ErrInvalidCOFF)
+	}
+	strtab := data[symtabEnd:]
+	if size := int(binary.LittleEndian.Uint32(strtab)); size >= 4 && size <= len(strtab) {
+		strtab = strtab[:size]
+	}
+
+	for i := 0; i < numSymbols; i++ {
+		sym := data[symtabOffset+i*coffSymbolSize:]
+		section := int(int16(binary.LittleEndian.Uint16(sym[12:])))
+		class := sym[16]
+		aux := int(sym[17])
+
+		if class == coffSymClassExternal && section >= 0 {
+			name, err := coffSymbolName(sym[:8], strtab)
+			if err != nil {
+				return nil, err
+			}
+			f.Symbols = append(f.Symbols, COFFSymbol{Name: name, Section: section})
+		}
+		i += aux
+	}
+	return f, nil
+}
+
+// coffSymbolName reads a short name or a string table reference
+func coffSymbolName(raw, strtab []byte) (string, error) {
+	if binary.LittleEndian.Uint32(raw) != 0 {
+		return string(bytes.TrimRight(raw, "\x00")), nil
+	}
+	offset := int(binary.LittleEndian.Uint32(raw[4:]))
+	if offset < 4 || offset >= len(strtab) {
+		return "", fmt.Errorf("%w: symbol name offset %d is outside the string table", ErrInvalidCOFF, offset)
+	}
+	name := strtab[offset:]
+	if end := bytes.IndexByte(name, 0); end >= 0 {
+		name = name[:end]
+	}
+	return string(name), nil
+}
+
+// HasFunction reports whether the object defines an external symbol named
+// name, allowing for the leading underscore x86 compilers add
+func (f *COFFFile) HasFunction(name string) bool {
+	for _, sym := range f.Symbols {
+		if sym.Section > 0 && (sym.Name == name || f.Arch == "x86" && sym.Name == "_"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// definedFunctions lists the defined external symbols without the x86 underscore
+func (f *COFFFile) definedFunctions() []string {
+	var names []string
+	for _, sym := range f.Symbols {
+		if sym.Section > 0 {
+			name := sym.Name
+			if f.Arch == "x86" {
+				name = strings.TrimPrefix(name, "_")
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateBOF checks that data is a COFF object file defining entrypoint
+// (empty for "go") and returns its summary, so a malformed BOF or a wrong
+// entry point is reported before a task is spent on it
+func ValidateBOF(data []byte, entrypoint string) (*COFFFile, error) {
+	f, err := ParseCOFF(data)
+	if err != nil {
+		return nil, err
+	}
+	if entrypoint == "" {
+		entrypoint = "go"
+	}
+	if !f.HasFunction(entrypoint) {
+		defined := f.definedFunctions()
+		if len(defined) > 10 {
+			defined = append(defined[:10], "...")
+		}
+		return f, fmt.Errorf("%w: entrypoint %q not found (defined: %s)", ErrInvalidCOFF, entrypoint, strings.Join(defined, ", "))
+	}
+	return f, nil
+}
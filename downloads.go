@@ -0,0 +1,285 @@
+package csclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DownloadedFileDto represents a completed download in the team server's Downloads data model.
+// The API specification only guarantees Path; the other fields are filled when the server provides them.
+type DownloadedFileDto struct {
+	ID   string `json:"id,omitempty"`
+	BID  string `json:"bid,omitempty"`
+	Host string `json:"host,omitempty"`
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+	Size int64  `json:"size,omitempty"`
+	Date int64  `json:"date,omitempty"` // Milliseconds since the Unix epoch
+}
+
+// DownloadProgressDto represents a file download in progress on a beacon
+type DownloadProgressDto struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Received int64  `json:"received"`
+}
+
+// ListDownloads retrieves all files in the Downloads data model
+func (c *Client) ListDownloads(ctx context.Context) ([]DownloadedFileDto, error) {
+	var downloads []DownloadedFileDto
+	if err := c.doRequest(ctx, "GET", "/api/v1/data/downloads", nil, &downloads, true); err != nil {
+		return nil, fmt.Errorf("failed to list downloads: %w", err)
+	}
+	return downloads, nil
+}
+
+// ListActiveDownloads retrieves the downloads currently in progress on a beacon
+func (c *Client) ListActiveDownloads(ctx context.Context, bid string) ([]DownloadProgressDto, error) {
+	var downloads []DownloadProgressDto
+	path, err := beaconPath(bid, "/activeDownloads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active downloads: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &downloads, true); err != nil {
+		return nil, fmt.Errorf("failed to list active downloads: %w", err)
+	}
+	return downloads, nil
+}
+
+// FileDownloadCancelDto cancels downloads in progress by file name
+type FileDownloadCancelDto struct {
+	File string `json:"file"`
+}
+
+// CancelFileTransfer cancels the beacon's downloads in progress whose file
+// name matches filename. Wildcards are accepted, e.g. "*.vhdx".
+func (c *Client) CancelFileTransfer(ctx context.Context, bid, filename string) (*AsyncCommandResponse, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("failed to cancel file transfer: %w", &ValidationError{Field: "filename", Value: filename, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/cancelFileDownload")
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel file transfer: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, FileDownloadCancelDto{File: filename}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to cancel file transfer: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetDownload streams the content of a downloaded file to w without buffering it in memory
+func (c *Client) GetDownload(ctx context.Context, downloadID string, w io.Writer) (int64, error) {
+	escaped, err := escapePathParam("download ID", downloadID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get download: %w", err)
+	}
+	n, err := c.doStream(ctx, "/api/v1/data/downloads/"+escaped, w)
+	if err != nil {
+		return n, fmt.Errorf("failed to get download: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteDownload removes a file from the Downloads data model
+func (c *Client) DeleteDownload(ctx context.Context, downloadID string) error {
+	escaped, err := escapePathParam("download ID", downloadID)
+	if err != nil {
+		return fmt.Errorf("failed to delete download: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", "/api/v1/data/downloads/"+escaped, nil, nil, true); err != nil {
+		return fmt.Errorf("failed to delete download: %w", err)
+	}
+	return nil
+}
+
+// ErrDownloadNotFound is returned when no Downloads entry matches a download task yet
+var ErrDownloadNotFound = errors.New("no download found")
+
+// FindTaskDownload locates the Downloads entry produced by a download task.
+// Downloads are not linked to tasks in the data model, so they are matched by
+// beacon and by the file name in the task command; the newest match wins.
+// Errors wrap ErrDownloadNotFound when nothing matches.
+func (c *Client) FindTaskDownload(ctx context.Context, task *TaskSummaryDto) (*DownloadedFileDto, error) {
+	return c.findTaskDownload(ctx, task, nil)
+}
+
+// findTaskDownload is FindTaskDownload ignoring the entries whose downloadKey is in exclude
+func (c *Client) findTaskDownload(ctx context.Context, task *TaskSummaryDto, exclude map[string]bool) (*DownloadedFileDto, error) {
+	name := downloadTaskFileName(task.TaskCommand)
+	if name == "" {
+		return nil, fmt.Errorf("task %s is not a download task", task.TaskID)
+	}
+
+	downloads, err := c.ListDownloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *DownloadedFileDto
+	for i := range downloads {
+		d := &downloads[i]
+		if exclude[downloadKey(d)] || !downloadMatches(d, task.BID, name) {
+			continue
+		}
+		if found == nil || d.Date >= found.Date {
+			found = d
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w for task %s (%s)", ErrDownloadNotFound, task.TaskID, name)
+	}
+	if found.ID == "" {
+		return nil, fmt.Errorf("download for task %s has no ID", task.TaskID)
+	}
+	return found, nil
+}
+
+// downloadMatches reports whether a Downloads entry may be the file name downloaded by beacon bid
+func downloadMatches(d *DownloadedFileDto, bid, name string) bool {
+	if d.BID != "" && d.BID != bid {
+		return false
+	}
+	return strings.EqualFold(d.Name, name) || strings.EqualFold(remoteBase(d.Path), name)
+}
+
+// downloadKey identifies a Downloads entry across listings
+func downloadKey(d *DownloadedFileDto) string {
+	return d.ID + "\x00" + d.Path
+}
+
+// FetchDownloadedFile streams the file downloaded by a completed download task to w
+func (c *Client) FetchDownloadedFile(ctx context.Context, task *TaskSummaryDto, w io.Writer) (int64, error) {
+	download, err := c.FindTaskDownload(ctx, task)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch downloaded file: %w", err)
+	}
+	return c.GetDownload(ctx, download.ID, w)
+}
+
+// DownloadOption configures DownloadFile
+type DownloadOption func(*downloadConfig)
+
+// downloadConfig holds the settings used by DownloadFile
+type downloadConfig struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+	cleanup      bool
+}
+
+// WithDownloadTimeout bounds the time spent waiting for the transfer to
+// finish. It defaults to the TaskWait entry of the timeout profile; raise it
+// for large files.
+func WithDownloadTimeout(d time.Duration) DownloadOption {
+	return func(cfg *downloadConfig) { cfg.timeout = d }
+}
+
+// WithDownloadPollInterval sets the delay between checks of the Downloads data model
+func WithDownloadPollInterval(d time.Duration) DownloadOption {
+	return func(cfg *downloadConfig) { cfg.pollInterval = d }
+}
+
+// WithDownloadCleanup deletes the file from the team server's Downloads once it has been streamed
+func WithDownloadCleanup() DownloadOption {
+	return func(cfg *downloadConfig) { cfg.cleanup = true }
+}
+
+// DownloadFile downloads a remote file and streams it to w. It queues the
+// download, waits for the task, waits for the file to appear in the Downloads
+// data model and streams its content, returning the number of bytes written.
+// Entries already in the Downloads data model before the download was queued
+// are never returned, so an earlier copy of the same file is not picked up.
+func (c *Client) DownloadFile(ctx context.Context, bid, remotePath string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	cfg := downloadConfig{pollInterval: taskPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	timeout := c.taskWaitTimeout(cfg.timeout)
+
+	name := remoteBase(remotePath)
+	existing, err := c.ListDownloads(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download file: %w", err)
+	}
+	seen := make(map[string]bool)
+	for i := range existing {
+		if downloadMatches(&existing[i], bid, name) {
+			seen[downloadKey(&existing[i])] = true
+		}
+	}
+
+	resp, err := c.Download(ctx, bid, remotePath)
+	if err != nil {
+		return 0, err
+	}
+	if resp.TaskID == "" {
+		return 0, fmt.Errorf("failed to download file: %s response did not include a task ID (status %q: %s)", resp.Name, resp.Status, resp.Message)
+	}
+
+	// The transfer, not the stream to w, is bounded by the timeout
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	task, err := c.WaitForTaskCompletion(waitCtx, resp.TaskID, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download file: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed || len(task.Error) > 0 {
+		msg := "task failed"
+		if len(task.Error) > 0 {
+			msg = task.Error[0].Message
+		}
+		return 0, fmt.Errorf("failed to download file: task %s: %s", task.TaskID, msg)
+	}
+
+	// Large files keep transferring after the task completes; the Downloads
+	// entry only appears once the last chunk has arrived. Only a missing entry
+	// is retried, any other error is permanent.
+	var download *DownloadedFileDto
+	for {
+		download, err = c.findTaskDownload(waitCtx, &task.TaskSummaryDto, seen)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrDownloadNotFound) {
+			return 0, fmt.Errorf("failed to download file: %w", err)
+		}
+		select {
+		case <-waitCtx.Done():
+			return 0, fmt.Errorf("failed to download file: %w (last lookup: %v)", waitCtx.Err(), err)
+		case <-time.After(cfg.pollInterval):
+		}
+	}
+
+	n, err := c.GetDownload(ctx, download.ID, w)
+	if err != nil {
+		return n, err
+	}
+	if cfg.cleanup {
+		if err := c.DeleteDownload(ctx, download.ID); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// downloadTaskFileName extracts the base name of the remote file from a download task command
+func downloadTaskFileName(command string) string {
+	verb, arg, ok := strings.Cut(strings.TrimSpace(command), " ")
+	if !ok || !strings.EqualFold(verb, "download") {
+		return ""
+	}
+	return remoteBase(strings.Trim(strings.TrimSpace(arg), `"`))
+}
+
+// remoteBase returns the last element of a remote path using either separator
+func remoteBase(path string) string {
+	if i := strings.LastIndexAny(path, `\/`); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
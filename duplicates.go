@@ -0,0 +1,179 @@
+package csclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DuplicateMode controls how identical submissions to the same beacon are handled
+type DuplicateMode int
+
+const (
+	DuplicateAllow  DuplicateMode = iota // Submit duplicates without checking (default)
+	DuplicateWarn                        // Report duplicates to the warning handler, then submit
+	DuplicateRefuse                      // Refuse duplicates with a *DuplicateTaskError
+)
+
+// DuplicateTaskError reports a submission identical to a task still pending on the beacon
+type DuplicateTaskError struct {
+	Path          string // Endpoint the command was submitted to
+	PendingTaskID string // Task ID of the identical pending task (empty while it is still being submitted)
+}
+
+func (e *DuplicateTaskError) Error() string {
+	if e.PendingTaskID == "" {
+		return fmt.Sprintf("identical command to %s is already being submitted", e.Path)
+	}
+	return fmt.Sprintf("identical command to %s is still pending as task %s", e.Path, e.PendingTaskID)
+}
+
+// SetDuplicateGuard enables detection of identical commands submitted to the
+// same beacon while a previous identical task has not finished. warn is called
+// for each duplicate in DuplicateWarn mode and may be nil.
+func (c *Client) SetDuplicateGuard(mode DuplicateMode, warn func(*DuplicateTaskError)) {
+	if mode == DuplicateAllow {
+		c.duplicates = nil
+		return
+	}
+	c.duplicates = &duplicateGuard{
+		mode:    mode,
+		warn:    warn,
+		pending: make(map[string]pendingSubmission),
+		byTask:  make(map[string]string),
+	}
+}
+
+// duplicatePendingTTL is how long a submission is remembered when its task is
+// never seen finishing. It covers beacons with long sleeps; older entries are
+// pruned so a long-running client does not grow the guard without bound.
+const duplicatePendingTTL = 24 * time.Hour
+
+// duplicateGuard tracks pending submissions by fingerprint
+type duplicateGuard struct {
+	mode    DuplicateMode
+	warn    func(*DuplicateTaskError)
+	mu      sync.Mutex
+	pending map[string]pendingSubmission // fingerprint -> submission
+	byTask  map[string]string            // task ID -> fingerprint
+}
+
+// pendingSubmission is a tracked submission and its task
+type pendingSubmission struct {
+	taskID string // Empty while the submission is in flight
+	added  time.Time
+}
+
+// reserve records key as in flight, replacing any previous submission.
+// Expired entries are pruned first. The caller holds g.mu.
+func (g *duplicateGuard) reserve(key string) {
+	now := time.Now()
+	for k, p := range g.pending {
+		if now.Sub(p.added) > duplicatePendingTTL {
+			g.forget(k)
+		}
+	}
+	g.forget(key)
+	g.pending[key] = pendingSubmission{added: now}
+}
+
+// forget removes a fingerprint and its task. The caller holds g.mu.
+func (g *duplicateGuard) forget(key string) {
+	if p, ok := g.pending[key]; ok {
+		delete(g.byTask, p.taskID)
+		delete(g.pending, key)
+	}
+}
+
+// finished forgets the submission of a task that reached a terminal status
+func (g *duplicateGuard) finished(task *TaskSummaryDto) {
+	if g == nil || !task.TaskStatus.IsTerminal() {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if key, ok := g.byTask[task.TaskID]; ok {
+		g.forget(key)
+	}
+}
+
+// submission identifies a request being tracked by the guard
+type submission struct {
+	key string
+}
+
+// begin checks a request for duplicates and reserves its fingerprint.
+// It returns a nil submission for requests that are not tracked.
+func (g *duplicateGuard) begin(ctx context.Context, c *Client, method, path string, body interface{}) (*submission, error) {
+	if g == nil || method != "POST" || !strings.HasPrefix(path, "/api/v1/beacons/") {
+		return nil, nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil
+	}
+	sum := sha256.Sum256(append([]byte(path+"\x00"), data...))
+	key := hex.EncodeToString(sum[:])
+
+	g.mu.Lock()
+	p, exists := g.pending[key]
+	if exists && time.Since(p.added) > duplicatePendingTTL {
+		exists = false
+	}
+	if !exists {
+		g.reserve(key)
+		g.mu.Unlock()
+		return &submission{key: key}, nil
+	}
+	g.mu.Unlock()
+	taskID := p.taskID
+
+	if taskID != "" {
+		task, err := c.GetTask(ctx, taskID)
+		if err == nil && task.TaskStatus.IsTerminal() {
+			exists = false
+		}
+	}
+
+	if exists {
+		dup := &DuplicateTaskError{Path: path, PendingTaskID: taskID}
+		if g.mode == DuplicateRefuse {
+			return nil, dup
+		}
+		if g.warn != nil {
+			g.warn(dup)
+		}
+	}
+
+	g.mu.Lock()
+	g.reserve(key)
+	g.mu.Unlock()
+	return &submission{key: key}, nil
+}
+
+// end records the task ID of a successful submission, or releases the fingerprint on failure
+func (g *duplicateGuard) end(s *submission, result interface{}, err error) {
+	if g == nil || s == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	resp, ok := result.(*AsyncCommandResponse)
+	if err != nil || !ok || resp.TaskID == "" {
+		g.forget(s.key)
+		return
+	}
+	p, ok := g.pending[s.key]
+	if !ok {
+		p.added = time.Now()
+	}
+	p.taskID = resp.TaskID
+	g.pending[s.key] = p
+	g.byTask[resp.TaskID] = s.key
+}
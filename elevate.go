@@ -0,0 +1,94 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// LocalExploitInfoDto describes a registered privilege elevation technique
+type LocalExploitInfoDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ElevateDto runs a session through an elevation exploit
+type ElevateDto struct {
+	Exploit  string `json:"exploit"`
+	Listener string `json:"listener"`
+}
+
+// RunAsAdminDto runs a command through an elevation technique
+type RunAsAdminDto struct {
+	Exploit   string `json:"exploit"`
+	Command   string `json:"command"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ListElevators lists the elevation exploits available to Elevate, including
+// those registered by Aggressor kits
+func (c *Client) ListElevators(ctx context.Context, bid string) ([]LocalExploitInfoDto, error) {
+	var exploits []LocalExploitInfoDto
+	path, err := beaconPath(bid, "/elevate/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list elevators: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &exploits, true); err != nil {
+		return nil, fmt.Errorf("failed to list elevators: %w", err)
+	}
+	return exploits, nil
+}
+
+// ListCommandElevators lists the elevation techniques available to RunAsAdmin
+func (c *Client) ListCommandElevators(ctx context.Context, bid string) ([]LocalExploitInfoDto, error) {
+	var exploits []LocalExploitInfoDto
+	path, err := beaconPath(bid, "/elevate/command")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list command elevators: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &exploits, true); err != nil {
+		return nil, fmt.Errorf("failed to list command elevators: %w", err)
+	}
+	return exploits, nil
+}
+
+// Elevate spawns an elevated session for listener using an elevation exploit
+func (c *Client) Elevate(ctx context.Context, bid, exploit, listener string) (*AsyncCommandResponse, error) {
+	if exploit == "" {
+		return nil, fmt.Errorf("failed to elevate: %w", &ValidationError{Field: "exploit", Value: exploit, Reason: "must not be empty"})
+	}
+	if listener == "" {
+		return nil, fmt.Errorf("failed to elevate: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/elevate/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to elevate: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, ElevateDto{Exploit: exploit, Listener: listener}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to elevate: %w", err)
+	}
+	return &resp, nil
+}
+
+// RunAsAdmin runs a command in an elevated context using an elevation technique
+func (c *Client) RunAsAdmin(ctx context.Context, bid, exploit, command string) (*AsyncCommandResponse, error) {
+	if exploit == "" {
+		return nil, fmt.Errorf("failed to execute runasadmin: %w", &ValidationError{Field: "exploit", Value: exploit, Reason: "must not be empty"})
+	}
+	program, arguments := splitCommandLine(command)
+	if program == "" {
+		return nil, fmt.Errorf("failed to execute runasadmin: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/elevate/command")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute runasadmin: %w", err)
+	}
+	req := RunAsAdminDto{Exploit: exploit, Command: program, Arguments: arguments}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute runasadmin: %w", err)
+	}
+	return &resp, nil
+}
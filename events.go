@@ -0,0 +1,176 @@
+package csclient
+
+import (
+	"context"
+	"time"
+)
+
+// EventTopic identifies a kind of event delivered by Subscribe
+type EventTopic string
+
+const (
+	TopicNewBeacon  EventTopic = "beacon.new"  // A beacon appeared
+	TopicTaskStatus EventTopic = "task.status" // A task was created or changed status
+	TopicTaskOutput EventTopic = "task.output" // A task produced new output
+	TopicError      EventTopic = "error"       // Polling failed; the subscription keeps retrying
+)
+
+// Event is delivered by Subscribe
+type Event struct {
+	Topic  EventTopic
+	Time   time.Time
+	Beacon *BeaconDto      // Set for TopicNewBeacon
+	Task   *TaskSummaryDto // Set for TopicTaskStatus and TopicTaskOutput
+	Output *OutputEntry    // Set for TopicTaskOutput
+	Err    error           // Set for TopicError
+}
+
+// SubscribeOption configures Subscribe
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	interval time.Duration
+}
+
+// WithSubscribeInterval sets how often the shared poll loop runs (default 5s)
+func WithSubscribeInterval(d time.Duration) SubscribeOption {
+	return func(cfg *subscribeConfig) { cfg.interval = d }
+}
+
+// Subscribe delivers beacon, task status and task output events for the given
+// topics (all topics when none are given) until ctx ends.
+//
+// The REST API does not expose a push event stream, so events are produced by a
+// single shared poll loop that delta-syncs tasks and beacons. This replaces the
+// per-beacon and per-task polling each tool would otherwise do. Transient
+// failures are reported as TopicError events and polling resumes on the next tick
+// from where it left off. Objects that exist when Subscribe is called do not
+// produce events. Output of tasks that already existed is reported from the
+// first poll that sees them change, so earlier output is not replayed.
+func (c *Client) Subscribe(ctx context.Context, topics []EventTopic, opts ...SubscribeOption) (<-chan Event, error) {
+	cfg := subscribeConfig{interval: 5 * time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	wanted := make(map[EventTopic]bool)
+	for _, topic := range topics {
+		wanted[topic] = true
+	}
+	want := func(topic EventTopic) bool {
+		return len(wanted) == 0 || wanted[topic] || topic == TopicError
+	}
+
+	// Prime the state so only changes after subscribing are reported
+	store := NewMemoryTaskStore()
+	if _, err := c.SyncTasks(ctx, store); err != nil {
+		return nil, err
+	}
+	beacons, err := c.ListBeacons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(beacons))
+	for _, b := range beacons {
+		known[b.BID] = true
+	}
+	// Output already present is not replayed: a pre-existing task's output is
+	// baselined the first time a poll reports it changed, so subscribing does
+	// not cost a request per task
+	offsets := make(map[string]int)
+	preexisting := make(map[string]bool)
+	if want(TopicTaskOutput) {
+		existing, err := store.All()
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range existing {
+			preexisting[task.TaskID] = true
+		}
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+
+		emit := func(e Event) bool {
+			if !want(e.Topic) {
+				return true
+			}
+			e.Time = time.Now()
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if want(TopicNewBeacon) {
+				beacons, err := c.ListBeacons(ctx)
+				if err != nil {
+					if !emit(Event{Topic: TopicError, Err: err}) {
+						return
+					}
+				}
+				for i := range beacons {
+					if known[beacons[i].BID] {
+						continue
+					}
+					known[beacons[i].BID] = true
+					if !emit(Event{Topic: TopicNewBeacon, Beacon: &beacons[i]}) {
+						return
+					}
+				}
+			}
+
+			if !want(TopicTaskStatus) && !want(TopicTaskOutput) {
+				continue
+			}
+			changed, err := c.SyncTasks(ctx, store)
+			if err != nil {
+				if !emit(Event{Topic: TopicError, Err: err}) {
+					return
+				}
+				continue
+			}
+			for i := range changed {
+				task := &changed[i]
+				if !emit(Event{Topic: TopicTaskStatus, Task: task}) {
+					return
+				}
+				if !want(TopicTaskOutput) {
+					continue
+				}
+				detail, err := c.GetTask(ctx, task.TaskID)
+				if err != nil {
+					if !emit(Event{Topic: TopicError, Err: err}) {
+						return
+					}
+					continue
+				}
+				if preexisting[task.TaskID] {
+					delete(preexisting, task.TaskID)
+					offsets[task.TaskID] = len(detail.Result)
+				}
+				for ; offsets[task.TaskID] < len(detail.Result); offsets[task.TaskID]++ {
+					entry := newOutputEntry(offsets[task.TaskID], detail.Result[offsets[task.TaskID]])
+					if !emit(Event{Topic: TopicTaskOutput, Task: task, Output: &entry}) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,43 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExecuteShellAndWait runs a shell command and waits for the task to finish
+func (c *Client) ExecuteShellAndWait(ctx context.Context, bid string, command string, timeout time.Duration) (*TaskDetailDto, error) {
+	resp, err := c.ExecuteShell(ctx, bid, command)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForResponse(ctx, resp, timeout)
+}
+
+// ExecutePowerShellAndWait runs a PowerShell command and waits for the task to finish
+func (c *Client) ExecutePowerShellAndWait(ctx context.Context, bid string, command string, timeout time.Duration) (*TaskDetailDto, error) {
+	resp, err := c.ExecutePowerShell(ctx, bid, command)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForResponse(ctx, resp, timeout)
+}
+
+// ExecuteBOFAndWait executes a BOF with typed arguments and waits for the task to finish
+func (c *Client) ExecuteBOFAndWait(ctx context.Context, bid string, req InlineExecutePackDto, timeout time.Duration) (*TaskDetailDto, error) {
+	resp, err := c.ExecuteBOFPack(ctx, bid, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForResponse(ctx, resp, timeout)
+}
+
+// waitForResponse waits for the task referenced by an async command response.
+// A non-positive timeout falls back to the TaskWait entry of the timeout profile.
+func (c *Client) waitForResponse(ctx context.Context, resp *AsyncCommandResponse, timeout time.Duration) (*TaskDetailDto, error) {
+	if resp.TaskID == "" {
+		return nil, fmt.Errorf("%s response did not include a task ID (status %q: %s)", resp.Name, resp.Status, resp.Message)
+	}
+	return c.WaitForTaskCompletion(ctx, resp.TaskID, c.taskWaitTimeout(timeout))
+}
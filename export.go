@@ -0,0 +1,168 @@
+package csclient
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format selects the output format of an export
+type Format string
+
+const (
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+	FormatJSONL    Format = "jsonl"
+	FormatMarkdown Format = "markdown"
+)
+
+// TaskExportRecord is a single exported task with the metadata of its beacon
+type TaskExportRecord struct {
+	TaskID   string     `json:"taskId"`
+	Operator string     `json:"operator"`
+	Command  string     `json:"command"`
+	Status   TaskStatus `json:"status"`
+	Created  time.Time  `json:"created"`
+	Updated  *time.Time `json:"updated,omitempty"`
+	Tactics  []string   `json:"tactics,omitempty"`
+	BID      string     `json:"bid"`
+	Computer string     `json:"computer,omitempty"`
+	User     string     `json:"beaconUser,omitempty"`
+	Internal string     `json:"internal,omitempty"`
+	External string     `json:"external,omitempty"`
+	Process  string     `json:"process,omitempty"`
+	PID      int        `json:"pid,omitempty"`
+}
+
+// taskExportColumns is the CSV header for task exports
+var taskExportColumns = []string{
+	"task_id", "operator", "command", "status", "created", "updated", "tactics",
+	"bid", "computer", "beacon_user", "internal", "external", "process", "pid",
+}
+
+// ExportTasks writes the tasks matching filter to w as CSV or JSON lines,
+// enriched with beacon metadata and MITRE ATT&CK tactics
+func (c *Client) ExportTasks(ctx context.Context, w io.Writer, format Format, filter TaskFilter) error {
+	records, err := c.TaskExportRecords(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(taskExportColumns); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		for _, r := range records {
+			updated := ""
+			if r.Updated != nil {
+				updated = r.Updated.Format(time.RFC3339)
+			}
+			row := []string{
+				r.TaskID, r.Operator, r.Command, string(r.Status), r.Created.Format(time.RFC3339), updated,
+				strings.Join(r.Tactics, ";"), r.BID, r.Computer, r.User, r.Internal, r.External, r.Process,
+				strconv.Itoa(r.PID),
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported task export format %q", format)
+	}
+	return nil
+}
+
+// TaskExportRecords collects the tasks matching filter together with their
+// beacon metadata and tactics. Task details are fetched once per beacon.
+func (c *Client) TaskExportRecords(ctx context.Context, filter TaskFilter) ([]TaskExportRecord, error) {
+	tasks, err := c.ListTasksFiltered(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	beacons, err := c.ListBeacons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	beaconsByID := make(map[string]*BeaconDto, len(beacons))
+	for i := range beacons {
+		beaconsByID[beacons[i].BID] = &beacons[i]
+	}
+
+	details, err := c.taskDetails(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]TaskExportRecord, 0, len(tasks))
+	for _, task := range tasks {
+		r := TaskExportRecord{
+			TaskID:   task.TaskID,
+			Operator: task.User,
+			Command:  task.TaskCommand,
+			Status:   task.TaskStatus,
+			Created:  task.Created,
+			Updated:  task.Updated,
+			BID:      task.BID,
+		}
+		if detail, ok := details[task.TaskID]; ok {
+			r.Tactics = detail.Tactics
+		}
+		if b, ok := beaconsByID[task.BID]; ok {
+			r.Computer = b.Computer
+			r.User = b.User
+			r.Internal = b.Internal
+			r.External = b.External
+			r.Process = b.Process
+			r.PID = b.PID
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// taskDetails fetches the details of the given tasks, keyed by task ID.
+// Details are listed once per beacon rather than once per task.
+func (c *Client) taskDetails(ctx context.Context, tasks []TaskSummaryDto) (map[string]*TaskDetailDto, error) {
+	wanted := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		wanted[task.TaskID] = true
+	}
+
+	details := make(map[string]*TaskDetailDto, len(tasks))
+	fetched := make(map[string]bool)
+	for _, task := range tasks {
+		if fetched[task.BID] {
+			continue
+		}
+		fetched[task.BID] = true
+		beaconTasks, err := c.GetBeaconTasksDetail(ctx, task.BID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range beaconTasks {
+			if wanted[beaconTasks[i].TaskID] {
+				details[beaconTasks[i].TaskID] = &beaconTasks[i]
+			}
+		}
+	}
+	return details, nil
+}
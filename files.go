@@ -0,0 +1,173 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeStompDto copies the timestamps of source onto destination
+type TimeStompDto struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// Timestomp sets the modified, accessed and created times of targetFile to
+// those of sourceFile, e.g. to blend an uploaded file in with its neighbours
+func (c *Client) Timestomp(ctx context.Context, bid, targetFile, sourceFile string) (*AsyncCommandResponse, error) {
+	if targetFile == "" {
+		return nil, fmt.Errorf("failed to timestomp: %w", &ValidationError{Field: "targetFile", Value: targetFile, Reason: "must not be empty"})
+	}
+	if sourceFile == "" {
+		return nil, fmt.Errorf("failed to timestomp: %w", &ValidationError{Field: "sourceFile", Value: sourceFile, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/timestomp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to timestomp: %w", err)
+	}
+	req := TimeStompDto{Source: sourceFile, Destination: targetFile}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to timestomp: %w", err)
+	}
+	return &resp, nil
+}
+
+// LsDto lists a directory on the target
+type LsDto struct {
+	Path string `json:"path,omitempty"`
+}
+
+// FileEntry is a file or directory returned by Ls
+type FileEntry struct {
+	Name     string
+	Path     string // Name joined to the listed directory
+	Size     int64
+	Modified time.Time // Zero if the beacon's timestamp could not be parsed
+	IsDir    bool
+}
+
+// lsTimeLayouts are the timestamp layouts the beacon uses in ls results
+var lsTimeLayouts = []string{"01/02/2006 15:04:05", "2006-01-02 15:04:05", time.RFC3339}
+
+// Ls lists a directory on the target (empty for the current working directory).
+// It submits the listing and waits for the beacon to return it; "." and ".."
+// are omitted.
+func (c *Client) Ls(ctx context.Context, bid, path string) ([]FileEntry, error) {
+	var resp AsyncCommandResponse
+	reqPath, err := beaconPath(bid, "/execute/ls")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", reqPath, LsDto{Path: path}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return nil, fmt.Errorf("failed to list directory: task %s failed", task.TaskID)
+	}
+	folders, err := DecodeTaskResult[FolderDto](task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+
+	var entries []FileEntry
+	for _, folder := range folders {
+		dir := strings.TrimSuffix(strings.TrimSuffix(folder.Folder, "*"), `\`)
+		for _, e := range folder.Contents {
+			if e.Name == "." || e.Name == ".." {
+				continue
+			}
+			entry := FileEntry{
+				Name:  e.Name,
+				Path:  e.Name,
+				Size:  e.Size,
+				IsDir: strings.EqualFold(e.Type, "D") || strings.EqualFold(e.Type, "dir") || strings.EqualFold(e.Type, "directory"),
+			}
+			if dir != "" {
+				entry.Path = dir + `\` + e.Name
+			}
+			for _, layout := range lsTimeLayouts {
+				if t, err := time.Parse(layout, e.Modified); err == nil {
+					entry.Modified = t
+					break
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// CdDto changes the beacon's working directory
+type CdDto struct {
+	Path string `json:"path"`
+}
+
+// Cd changes the beacon's working directory, which relative paths and Upload use
+func (c *Client) Cd(ctx context.Context, bid, path string) (*AsyncCommandResponse, error) {
+	if path == "" {
+		return nil, fmt.Errorf("failed to change directory: %w", &ValidationError{Field: "path", Value: path, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	reqPath, err := beaconPath(bid, "/execute/cd")
+	if err != nil {
+		return nil, fmt.Errorf("failed to change directory: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", reqPath, CdDto{Path: path}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to change directory: %w", err)
+	}
+	return &resp, nil
+}
+
+// Pwd returns the beacon's working directory. It submits the task and waits
+// for the beacon to report the directory.
+func (c *Client) Pwd(ctx context.Context, bid string) (string, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/pwd")
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return "", fmt.Errorf("failed to get working directory: task %s failed", task.TaskID)
+	}
+	for _, line := range outputLines(TaskOutputText(task)) {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "[*]"))
+		if dir, ok := strings.CutPrefix(line, "Current directory is "); ok {
+			return strings.TrimSpace(dir), nil
+		}
+	}
+	return "", fmt.Errorf("failed to get working directory: no directory in output of task %s", task.TaskID)
+}
+
+// Drives lists the drive letters on the target, e.g. "C:". It submits the task
+// and waits for the beacon to return the list.
+func (c *Client) Drives(ctx context.Context, bid string) ([]string, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/drives")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drives: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to list drives: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drives: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return nil, fmt.Errorf("failed to list drives: task %s failed", task.TaskID)
+	}
+	return ParseDrives(TaskOutputText(task)), nil
+}
@@ -0,0 +1,59 @@
+package csclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadFilesMap reads files and returns them base64 encoded, keyed by base name,
+// for the Files map of BOF, command and upload DTOs. Reference an entry as
+// "@files/<key>"; characters the API rejects in keys are replaced with '_'.
+// Two paths mapping to the same key are an error.
+func LoadFilesMap(paths ...string) (map[string]string, error) {
+	files := make(map[string]string, len(paths))
+	sources := make(map[string]string, len(paths))
+	for _, path := range paths {
+		key := FilesKey(path)
+		if other, dup := sources[key]; dup {
+			return nil, fmt.Errorf("failed to load files: %w", &ValidationError{Field: "path", Value: path, Reason: fmt.Sprintf("maps to the same key %q as %s", key, other)})
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load files: %w", err)
+		}
+		files[key] = base64.StdEncoding.EncodeToString(data)
+		sources[key] = path
+	}
+	return files, nil
+}
+
+// LoadFilesFromDir loads every regular file directly inside dir with
+// LoadFilesMap. Subdirectories are not descended into.
+func LoadFilesFromDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load files: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return LoadFilesMap(paths...)
+}
+
+// FilesMapSize returns the decoded and encoded sizes in bytes of a Files map,
+// for checking a request against the team server's body size limit
+func FilesMapSize(files map[string]string) (decoded, encoded int64) {
+	for _, content := range files {
+		encoded += int64(len(content))
+		decoded += int64(base64.StdEncoding.DecodedLen(len(content)) - strings.Count(content[max(0, len(content)-2):], "="))
+	}
+	return decoded, encoded
+}
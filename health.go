@@ -0,0 +1,141 @@
+package csclient
+
+import (
+	"context"
+	"time"
+)
+
+// HealthState classifies a beacon's check-in behaviour
+type HealthState string
+
+const (
+	HealthHealthy      HealthState = "healthy"       // Checked in within its sleep interval
+	HealthLate         HealthState = "late"          // Missed its expected check-in
+	HealthPresumedDead HealthState = "presumed-dead" // Missed several check-ins or is no longer alive
+)
+
+// HealthPolicy controls how beacon check-in delays are classified
+type HealthPolicy struct {
+	Grace     time.Duration // Allowed delay past the sleep interval before a beacon is late
+	DeadAfter int           // Number of missed sleep intervals before a beacon is presumed dead
+	MinDead   time.Duration // Minimum delay before a beacon is presumed dead (covers short sleeps)
+}
+
+// DefaultHealthPolicy is used by CheckBeaconHealth and when a watcher is given a zero policy
+var DefaultHealthPolicy = HealthPolicy{
+	Grace:     30 * time.Second,
+	DeadAfter: 3,
+	MinDead:   5 * time.Minute,
+}
+
+// BeaconHealth is the health of a single beacon
+type BeaconHealth struct {
+	BID         string
+	State       HealthState
+	LastCheckin time.Time
+	ExpectedBy  time.Time     // Latest time the next check-in was expected (sleep + grace)
+	Since       time.Duration // Time since the last check-in, as reported by the server
+	Overdue     time.Duration // Time past ExpectedBy, zero when not late
+}
+
+// CheckBeaconHealth classifies a beacon using DefaultHealthPolicy
+func CheckBeaconHealth(b *BeaconDto) BeaconHealth {
+	return DefaultHealthPolicy.Evaluate(b)
+}
+
+// Evaluate classifies a beacon. Jitter only shortens the sleep, so the sleep
+// time is the longest expected interval. The elapsed time comes from
+// LastCheckinMs, which the server computes, so client clock skew does not matter.
+func (p HealthPolicy) Evaluate(b *BeaconDto) BeaconHealth {
+	interval := time.Duration(b.Sleep.Sleep) * time.Second
+	since := time.Duration(b.LastCheckinMs) * time.Millisecond
+	h := BeaconHealth{
+		BID:         b.BID,
+		State:       HealthHealthy,
+		LastCheckin: b.LastCheckinTime,
+		ExpectedBy:  b.LastCheckinTime.Add(interval + p.Grace),
+		Since:       since,
+	}
+
+	deadAfter := time.Duration(p.DeadAfter) * interval
+	if deadAfter < p.MinDead {
+		deadAfter = p.MinDead
+	}
+
+	if late := since - (interval + p.Grace); late > 0 {
+		h.State = HealthLate
+		h.Overdue = late
+	}
+	if !b.Alive || (deadAfter > 0 && since > deadAfter+p.Grace) {
+		h.State = HealthPresumedDead
+	}
+	return h
+}
+
+// HealthTransition is emitted by WatchBeaconHealth when a beacon's state changes
+type HealthTransition struct {
+	From   HealthState // Empty the first time a beacon is seen
+	To     HealthState
+	Health BeaconHealth
+	Beacon *BeaconDto
+	Err    error // Set when listing beacons failed; watching continues
+}
+
+// WatchBeaconHealth lists beacons every interval and emits a transition whenever a
+// beacon's health state changes. Every beacon produces one transition when it is
+// first seen. The channel is closed when ctx ends.
+func (c *Client) WatchBeaconHealth(ctx context.Context, interval time.Duration, policy HealthPolicy) (<-chan HealthTransition, error) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	if policy == (HealthPolicy{}) {
+		policy = DefaultHealthPolicy
+	}
+
+	transitions := make(chan HealthTransition)
+	go func() {
+		defer close(transitions)
+		states := make(map[string]HealthState)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		emit := func(t HealthTransition) bool {
+			select {
+			case transitions <- t:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			beacons, err := c.ListBeacons(ctx)
+			if err != nil {
+				if ctx.Err() != nil || !emit(HealthTransition{Err: err}) {
+					return
+				}
+			} else {
+				for i := range beacons {
+					b := beacons[i]
+					h := policy.Evaluate(&b)
+					from, seen := states[b.BID]
+					if seen && from == h.State {
+						continue
+					}
+					states[b.BID] = h.State
+					if !emit(HealthTransition{From: from, To: h.State, Health: h, Beacon: &b}) {
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return transitions, nil
+}
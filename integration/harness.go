@@ -0,0 +1,284 @@
+// Package integration provides an opt-in harness that exercises csclient
+// against a live development team server and records timing baselines.
+//
+// The harness only runs when CSREST_URL is set, so it is safe to wire into
+// CI jobs that may not have a team server available.
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	csclient "github.com/xenov-x/csrest"
+)
+
+// Environment variables recognised by ConfigFromEnv
+const (
+	EnvURL         = "CSREST_URL"          // Base URL of the team server API (required)
+	EnvUser        = "CSREST_USER"         // Operator username (required)
+	EnvPassword    = "CSREST_PASSWORD"     // Operator password (required)
+	EnvBeacon      = "CSREST_BEACON"       // Beacon ID used for task and file steps (optional)
+	EnvInsecure    = "CSREST_INSECURE"     // Skip TLS verification when "1" or "true"
+	EnvTaskTimeout = "CSREST_TASK_TIMEOUT" // Task completion timeout, e.g. "2m"
+)
+
+// Config holds the connection details for a harness run
+type Config struct {
+	BaseURL     string
+	Username    string
+	Password    string
+	BeaconID    string
+	Insecure    bool
+	TaskTimeout time.Duration
+}
+
+// ConfigFromEnv builds a Config from the environment.
+// It returns false when CSREST_URL is unset, meaning the harness should be skipped.
+func ConfigFromEnv() (Config, bool, error) {
+	cfg := Config{
+		BaseURL:     os.Getenv(EnvURL),
+		Username:    os.Getenv(EnvUser),
+		Password:    os.Getenv(EnvPassword),
+		BeaconID:    os.Getenv(EnvBeacon),
+		TaskTimeout: 2 * time.Minute,
+	}
+	if cfg.BaseURL == "" {
+		return cfg, false, nil
+	}
+	if cfg.Username == "" || cfg.Password == "" {
+		return cfg, true, fmt.Errorf("%s and %s must be set", EnvUser, EnvPassword)
+	}
+	if v := os.Getenv(EnvInsecure); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, true, fmt.Errorf("invalid %s: %w", EnvInsecure, err)
+		}
+		cfg.Insecure = insecure
+	}
+	if v := os.Getenv(EnvTaskTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, true, fmt.Errorf("invalid %s: %w", EnvTaskTimeout, err)
+		}
+		cfg.TaskTimeout = d
+	}
+	return cfg, true, nil
+}
+
+// StepResult records the outcome of a single harness step
+type StepResult struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Report is the outcome of a harness run
+type Report struct {
+	Started time.Time    `json:"started"`
+	Steps   []StepResult `json:"steps"`
+}
+
+// Failed reports whether any step returned an error
+func (r *Report) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteBaseline writes the step timings as JSON so later runs can be compared against them
+func (r *Report) WriteBaseline(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ReadBaseline reads a report previously written by WriteBaseline
+func ReadBaseline(r io.Reader) (*Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	return &report, nil
+}
+
+// Regression describes a step that became slower than its baseline allows
+type Regression struct {
+	Step     string
+	Baseline time.Duration
+	Current  time.Duration
+}
+
+// CompareBaseline returns the steps whose duration exceeds the baseline by more
+// than the given factor (e.g. 1.5 allows a 50% slowdown)
+func (r *Report) CompareBaseline(baseline *Report, factor float64) []Regression {
+	previous := make(map[string]time.Duration, len(baseline.Steps))
+	for _, step := range baseline.Steps {
+		if !step.Skipped && step.Error == "" {
+			previous[step.Name] = step.Duration
+		}
+	}
+
+	var regressions []Regression
+	for _, step := range r.Steps {
+		base, ok := previous[step.Name]
+		if !ok || step.Skipped || step.Error != "" {
+			continue
+		}
+		if float64(step.Duration) > float64(base)*factor {
+			regressions = append(regressions, Regression{
+				Step:     step.Name,
+				Baseline: base,
+				Current:  step.Duration,
+			})
+		}
+	}
+	return regressions
+}
+
+// Run exercises login, beacon listing, the task lifecycle and an upload that is
+// downloaded back, compared and removed from the target, against the configured
+// team server. Steps that need a beacon are skipped when no beacon ID is
+// configured. Later steps are skipped once a step fails, except the removal of
+// an uploaded file.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	client, err := csclient.NewClientWithBaseURL(cfg.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Insecure {
+		client.SetHTTPClient(&http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		})
+	}
+
+	h := &harness{client: client, cfg: cfg, report: &Report{Started: time.Now()}}
+
+	h.step(ctx, "login", false, func(ctx context.Context) error {
+		_, err := client.Login(ctx, cfg.Username, cfg.Password, 0)
+		return err
+	})
+	h.step(ctx, "list_beacons", false, func(ctx context.Context) error {
+		_, err := client.ListBeacons(ctx)
+		return err
+	})
+	h.step(ctx, "list_tasks", false, func(ctx context.Context) error {
+		_, err := client.ListTasks(ctx)
+		return err
+	})
+
+	noBeacon := cfg.BeaconID == ""
+	h.step(ctx, "get_beacon", noBeacon, func(ctx context.Context) error {
+		_, err := client.GetBeacon(ctx, cfg.BeaconID)
+		return err
+	})
+	h.step(ctx, "task_lifecycle", noBeacon, func(ctx context.Context) error {
+		resp, err := client.GetUID(ctx, cfg.BeaconID)
+		if err != nil {
+			return err
+		}
+		return h.waitTask(ctx, resp)
+	})
+
+	remoteName := fmt.Sprintf("csrest-it-%d.txt", h.report.Started.Unix())
+	content := []byte("csrest integration harness " + h.report.Started.Format(time.RFC3339Nano) + "\n")
+	uploaded := false
+	h.step(ctx, "upload", noBeacon, func(ctx context.Context) error {
+		dir, err := os.MkdirTemp("", "csrest-it")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		local := filepath.Join(dir, remoteName)
+		if err := os.WriteFile(local, content, 0o600); err != nil {
+			return err
+		}
+		resp, err := client.Upload(ctx, cfg.BeaconID, local)
+		if err != nil {
+			return err
+		}
+		// The file may exist even if waiting fails, so it is always cleaned up
+		uploaded = true
+		return h.waitTask(ctx, resp)
+	})
+	h.step(ctx, "download", noBeacon, func(ctx context.Context) error {
+		var buf bytes.Buffer
+		if _, err := client.DownloadFile(ctx, cfg.BeaconID, remoteName, &buf, csclient.WithDownloadTimeout(cfg.TaskTimeout), csclient.WithDownloadCleanup()); err != nil {
+			return err
+		}
+		if !bytes.Equal(buf.Bytes(), content) {
+			return fmt.Errorf("downloaded %d bytes that differ from the %d bytes uploaded", buf.Len(), len(content))
+		}
+		return nil
+	})
+	h.cleanup(ctx, "remove_upload", !uploaded, func(ctx context.Context) error {
+		resp, err := client.ExecuteConsoleCommand(ctx, cfg.BeaconID, csclient.CommandDto{Command: "rm", Arguments: remoteName})
+		if err != nil {
+			return err
+		}
+		return h.waitTask(ctx, resp)
+	})
+
+	return h.report, nil
+}
+
+// harness carries state shared by the steps of a run
+type harness struct {
+	client *csclient.Client
+	cfg    Config
+	report *Report
+	failed bool
+}
+
+// step runs fn and records its timing, skipping it when requested or after an earlier failure
+func (h *harness) step(ctx context.Context, name string, skip bool, fn func(context.Context) error) {
+	if skip || h.failed || ctx.Err() != nil {
+		h.report.Steps = append(h.report.Steps, StepResult{Name: name, Skipped: true})
+		return
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+	result := StepResult{Name: name, Duration: time.Since(start)}
+	if err != nil {
+		result.Error = err.Error()
+		h.failed = true
+	}
+	h.report.Steps = append(h.report.Steps, result)
+}
+
+// cleanup runs fn like step, but also after an earlier step failed
+func (h *harness) cleanup(ctx context.Context, name string, skip bool, fn func(context.Context) error) {
+	failed := h.failed
+	h.failed = false
+	h.step(ctx, name, skip, fn)
+	h.failed = h.failed || failed
+}
+
+// waitTask waits for an async command to finish and converts a failed task into an error
+func (h *harness) waitTask(ctx context.Context, resp *csclient.AsyncCommandResponse) error {
+	task, err := h.client.WaitForTaskCompletion(ctx, resp.TaskID, h.cfg.TaskTimeout)
+	if err != nil {
+		return err
+	}
+	if task.TaskStatus == csclient.TaskStatusFailed {
+		return fmt.Errorf("task %s failed", task.TaskID)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// envBaseline names a baseline file: compared against when it exists, written otherwise
+const envBaseline = "CSREST_BASELINE"
+
+func TestHarness(t *testing.T) {
+	cfg, enabled, err := ConfigFromEnv()
+	if !enabled {
+		t.Skipf("%s is not set; skipping the live team server harness", EnvURL)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	report, err := Run(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range report.Steps {
+		switch {
+		case step.Skipped:
+			t.Logf("%-16s skipped", step.Name)
+		case step.Error != "":
+			t.Errorf("%-16s failed after %v: %s", step.Name, step.Duration, step.Error)
+		default:
+			t.Logf("%-16s %v", step.Name, step.Duration)
+		}
+	}
+	if report.Failed() {
+		return
+	}
+
+	path := os.Getenv(envBaseline)
+	if path == "" {
+		return
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		out, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Close()
+		if err := report.WriteBaseline(out); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("wrote baseline %s", path)
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	baseline, err := ReadBaseline(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range report.CompareBaseline(baseline, 1.5) {
+		t.Errorf("%s regressed: %v (baseline %v)", r.Step, r.Current, r.Baseline)
+	}
+}
@@ -0,0 +1,130 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SessionType identifies the kind of session behind a BeaconDto
+type SessionType string
+
+const (
+	SessionTypeBeacon SessionType = "beacon"
+	SessionTypeSSH    SessionType = "ssh"
+)
+
+// SessionType returns the type of session the beacon is running as
+func (b *BeaconDto) SessionType() SessionType {
+	if strings.EqualFold(b.Session, string(SessionTypeSSH)) {
+		return SessionTypeSSH
+	}
+	return SessionTypeBeacon
+}
+
+// Intent is a high-level operation that is translated into the appropriate
+// console command for the session type it runs on
+type Intent string
+
+const (
+	IntentListProcesses Intent = "list-processes" // No arguments
+	IntentReadFile      Intent = "read-file"      // Argument: path of the file to read
+	IntentHostInfo      Intent = "host-info"      // No arguments
+)
+
+// intentTranslator builds the console command for an intent
+type intentTranslator func(args []string) (CommandDto, error)
+
+// intentTable maps each session type and intent to its console command
+var intentTable = map[SessionType]map[Intent]intentTranslator{
+	SessionTypeBeacon: {
+		IntentListProcesses: fixedCommand("ps", ""),
+		IntentReadFile:      pathCommand("shell", "type %s", quoteWindowsPath),
+		IntentHostInfo:      fixedCommand("shell", "systeminfo"),
+	},
+	SessionTypeSSH: {
+		IntentListProcesses: fixedCommand("shell", "ps -eo pid,ppid,user,args"),
+		IntentReadFile:      pathCommand("shell", "cat -- %s", quotePOSIXPath),
+		IntentHostInfo:      fixedCommand("shell", "uname -a; id; hostname; cat /etc/os-release"),
+	},
+}
+
+// fixedCommand returns a translator for an intent that takes no arguments
+func fixedCommand(command, arguments string) intentTranslator {
+	return func(args []string) (CommandDto, error) {
+		if len(args) != 0 {
+			return CommandDto{}, fmt.Errorf("intent takes no arguments")
+		}
+		return CommandDto{Command: command, Arguments: arguments}, nil
+	}
+}
+
+// pathCommand returns a translator for an intent that takes a single path,
+// quoted for the session's shell by quote
+func pathCommand(command, format string, quote func(path string) (string, error)) intentTranslator {
+	return func(args []string) (CommandDto, error) {
+		if len(args) != 1 || args[0] == "" {
+			return CommandDto{}, fmt.Errorf("intent requires a single path argument")
+		}
+		quoted, err := quote(args[0])
+		if err != nil {
+			return CommandDto{}, err
+		}
+		return CommandDto{Command: command, Arguments: fmt.Sprintf(format, quoted)}, nil
+	}
+}
+
+// quotePOSIXPath single-quotes a path for a POSIX shell, so no expansion
+// takes place inside it
+func quotePOSIXPath(path string) (string, error) {
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'", nil
+}
+
+// quoteWindowsPath double-quotes a path for cmd.exe. cmd.exe has no escape
+// inside double quotes, so quotes and metacharacters are rejected.
+func quoteWindowsPath(path string) (string, error) {
+	if strings.ContainsAny(path, "\"&|<>^%") {
+		return "", &ValidationError{Field: "path", Value: path, Reason: "must not contain quotes or cmd.exe metacharacters"}
+	}
+	for _, r := range path {
+		if r < 0x20 || r == 0x7f {
+			return "", &ValidationError{Field: "path", Value: path, Reason: "contains control characters"}
+		}
+	}
+	return `"` + path + `"`, nil
+}
+
+// IntentCommand returns the console command that implements the intent for the given beacon
+func IntentCommand(beacon *BeaconDto, intent Intent, args ...string) (CommandDto, error) {
+	translators, ok := intentTable[beacon.SessionType()]
+	if !ok {
+		return CommandDto{}, fmt.Errorf("unsupported session type %q", beacon.Session)
+	}
+	translate, ok := translators[intent]
+	if !ok {
+		return CommandDto{}, fmt.Errorf("intent %q is not supported on %s sessions", intent, beacon.SessionType())
+	}
+	cmd, err := translate(args)
+	if err != nil {
+		return CommandDto{}, fmt.Errorf("intent %q: %w", intent, err)
+	}
+	return cmd, nil
+}
+
+// ExecuteIntent looks up the beacon and runs the intent using the primitive appropriate to its session type
+func (c *Client) ExecuteIntent(ctx context.Context, bid string, intent Intent, args ...string) (*AsyncCommandResponse, error) {
+	beacon, err := c.GetBeacon(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExecuteIntentOn(ctx, beacon, intent, args...)
+}
+
+// ExecuteIntentOn runs the intent on an already fetched beacon, avoiding an extra lookup
+func (c *Client) ExecuteIntentOn(ctx context.Context, beacon *BeaconDto, intent Intent, args ...string) (*AsyncCommandResponse, error) {
+	cmd, err := IntentCommand(beacon, intent, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute intent: %w", err)
+	}
+	return c.ExecuteConsoleCommand(ctx, beacon.BID, cmd)
+}
@@ -0,0 +1,50 @@
+package csclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIntentReadFileQuoting(t *testing.T) {
+	beacon := &BeaconDto{Session: "beacon"}
+	ssh := &BeaconDto{Session: "ssh"}
+	tests := []struct {
+		name    string
+		beacon  *BeaconDto
+		path    string
+		want    string
+		invalid bool
+	}{
+		{"beacon plain", beacon, `C:\Users\a b\file.txt`, `type "C:\Users\a b\file.txt"`, false},
+		{"beacon quote", beacon, `C:\x" & calc & "`, "", true},
+		{"beacon ampersand", beacon, `C:\a&b.txt`, "", true},
+		{"beacon pipe", beacon, `C:\a|b.txt`, "", true},
+		{"beacon redirect", beacon, `C:\a>b.txt`, "", true},
+		{"beacon caret", beacon, `C:\a^b.txt`, "", true},
+		{"beacon percent", beacon, `C:\%TEMP%\a.txt`, "", true},
+		{"beacon newline", beacon, "C:\\a\nb.txt", "", true},
+		{"ssh plain", ssh, "/etc/passwd", "cat -- '/etc/passwd'", false},
+		{"ssh substitution", ssh, "/tmp/$(id).txt", "cat -- '/tmp/$(id).txt'", false},
+		{"ssh backticks", ssh, "/tmp/`id`", "cat -- '/tmp/`id`'", false},
+		{"ssh single quote", ssh, "/tmp/it's", `cat -- '/tmp/it'\''s'`, false},
+		{"ssh double quote", ssh, `/tmp/a"b`, `cat -- '/tmp/a"b'`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := IntentCommand(tt.beacon, IntentReadFile, tt.path)
+			if tt.invalid {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("got %v, want *ValidationError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cmd.Command != "shell" || cmd.Arguments != tt.want {
+				t.Fatalf("got %q %q, want shell %q", cmd.Command, cmd.Arguments, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JobKillDto stops a beacon job
+type JobKillDto struct {
+	JID int `json:"jid"`
+}
+
+// ListJobs lists the beacon's running jobs (keyloggers, port scans, ...). It
+// submits the listing and waits for the beacon to report it.
+func (c *Client) ListJobs(ctx context.Context, bid string) ([]JobInfoDto, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/jobs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	infos, err := DecodeTaskResult[JobsInfoDto](task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	var jobs []JobInfoDto
+	for _, info := range infos {
+		jobs = append(jobs, info.Jobs...)
+	}
+	return jobs, nil
+}
+
+// JobKill stops the job with the given job ID
+func (c *Client) JobKill(ctx context.Context, bid string, jid int) (*AsyncCommandResponse, error) {
+	if jid < 0 {
+		return nil, fmt.Errorf("failed to kill job: %w", &ValidationError{Field: "jid", Value: strconv.Itoa(jid), Reason: "must not be negative"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/jobStop")
+	if err != nil {
+		return nil, fmt.Errorf("failed to kill job: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, JobKillDto{JID: jid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to kill job: %w", err)
+	}
+	return &resp, nil
+}
+
+// stopJobsMatching stops every job whose description contains substr (case-insensitive)
+func (c *Client) stopJobsMatching(ctx context.Context, bid, substr string) ([]*AsyncCommandResponse, error) {
+	jobs, err := c.ListJobs(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	var responses []*AsyncCommandResponse
+	for _, job := range jobs {
+		if !strings.Contains(strings.ToLower(job.Description), strings.ToLower(substr)) {
+			continue
+		}
+		resp, err := c.JobKill(ctx, bid, job.JID)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, resp)
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no %s job is running", substr)
+	}
+	return responses, nil
+}
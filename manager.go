@@ -0,0 +1,170 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Manager coordinates clients connected to several team servers
+type Manager struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewManager creates an empty multi-server manager
+func NewManager() *Manager {
+	return &Manager{clients: make(map[string]*Client)}
+}
+
+// Add registers an authenticated client under a server name, replacing any existing one
+func (m *Manager) Add(name string, client *Client) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[name] = client
+}
+
+// Remove unregisters a server
+func (m *Manager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, name)
+}
+
+// Client returns the client registered under name
+func (m *Manager) Client(name string) (*Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.clients[name]
+	return client, ok
+}
+
+// Servers returns the registered server names in sorted order
+func (m *Manager) Servers() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.clients))
+	for name := range m.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListBeacons lists beacons on every server concurrently, keyed by server name.
+// Servers that fail are reported in the returned error; the others are still returned.
+func (m *Manager) ListBeacons(ctx context.Context) (map[string][]BeaconDto, error) {
+	m.mu.RLock()
+	clients := make(map[string]*Client, len(m.clients))
+	for name, client := range m.clients {
+		clients[name] = client
+	}
+	m.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed []string
+	)
+	snapshot := make(map[string][]BeaconDto, len(clients))
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *Client) {
+			defer wg.Done()
+			beacons, err := client.ListBeacons(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+				return
+			}
+			snapshot[name] = beacons
+		}(name, client)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return snapshot, fmt.Errorf("failed to list beacons on %d server(s): %s", len(failed), strings.Join(failed, "; "))
+	}
+	return snapshot, nil
+}
+
+// ServerBeacon is a beacon together with the server it was seen on
+type ServerBeacon struct {
+	Server string
+	Beacon BeaconDto
+}
+
+// DuplicateHost is a host that has sessions on more than one team server
+type DuplicateHost struct {
+	Computer string
+	Internal string
+	Users    []string       // Distinct users across the sessions
+	Sessions []ServerBeacon // Every session on the host, across all servers
+}
+
+// CrossServerDedup lists beacons on every server and flags hosts that appear on
+// more than one of them, so they are not tasked twice during migrations.
+// Partial results are used when some servers fail.
+func (m *Manager) CrossServerDedup(ctx context.Context) ([]DuplicateHost, error) {
+	snapshot, err := m.ListBeacons(ctx)
+	if len(snapshot) == 0 && err != nil {
+		return nil, err
+	}
+	return FindCrossServerDuplicates(snapshot), err
+}
+
+// FindCrossServerDuplicates identifies hosts (matched by hostname and internal IP)
+// that have sessions on two or more servers in a snapshot keyed by server name
+func FindCrossServerDuplicates(snapshot map[string][]BeaconDto) []DuplicateHost {
+	hosts := make(map[string]*DuplicateHost)
+	servers := make(map[string]map[string]bool)
+
+	for server, beacons := range snapshot {
+		for _, beacon := range beacons {
+			key := strings.ToLower(beacon.Computer) + "|" + beacon.Internal
+			host, ok := hosts[key]
+			if !ok {
+				host = &DuplicateHost{Computer: beacon.Computer, Internal: beacon.Internal}
+				hosts[key] = host
+				servers[key] = make(map[string]bool)
+			}
+			host.Sessions = append(host.Sessions, ServerBeacon{Server: server, Beacon: beacon})
+			servers[key][server] = true
+		}
+	}
+
+	var duplicates []DuplicateHost
+	for key, host := range hosts {
+		if len(servers[key]) < 2 {
+			continue
+		}
+		users := make(map[string]bool)
+		for _, session := range host.Sessions {
+			if !users[session.Beacon.User] {
+				users[session.Beacon.User] = true
+				host.Users = append(host.Users, session.Beacon.User)
+			}
+		}
+		sort.Strings(host.Users)
+		sort.Slice(host.Sessions, func(i, j int) bool {
+			if host.Sessions[i].Server != host.Sessions[j].Server {
+				return host.Sessions[i].Server < host.Sessions[j].Server
+			}
+			return host.Sessions[i].Beacon.BID < host.Sessions[j].Beacon.BID
+		})
+		duplicates = append(duplicates, *host)
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Computer != duplicates[j].Computer {
+			return duplicates[i].Computer < duplicates[j].Computer
+		}
+		return duplicates[i].Internal < duplicates[j].Internal
+	})
+	return duplicates
+}
@@ -0,0 +1,135 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// The spec defines one DTO per net command (NetComputersDto, NetShareDto,
+// NetLocalGroupDto, ...) but they only come in these three shapes.
+
+// NetDomainDto is the request body of the domain-scoped net commands
+type NetDomainDto struct {
+	Domain string `json:"domain,omitempty"`
+}
+
+// NetTargetDto is the request body of the host-scoped net commands
+type NetTargetDto struct {
+	Target string `json:"target,omitempty"`
+}
+
+// NetGroupDto is the request body of net group and net localgroup
+type NetGroupDto struct {
+	Target    string `json:"target,omitempty"`
+	GroupName string `json:"groupName,omitempty"`
+}
+
+// netCommand runs a net command in a spawned process, waits for it and returns its output
+func (c *Client) netCommand(ctx context.Context, bid, name, endpoint string, body interface{}) (string, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/net/"+endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to run net %s: %w", name, err)
+	}
+	if err := c.doRequest(ctx, "POST", path, body, &resp, true); err != nil {
+		return "", fmt.Errorf("failed to run net %s: %w", name, err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to run net %s: %w", name, err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return "", fmt.Errorf("failed to run net %s: task %s failed", name, task.TaskID)
+	}
+	return TaskOutputText(task), nil
+}
+
+// NetComputers lists the computer accounts of a domain (empty for the current domain)
+func (c *Client) NetComputers(ctx context.Context, bid, domain string) ([]NetHost, error) {
+	output, err := c.netCommand(ctx, bid, "computers", "computers", NetDomainDto{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetHosts(output), nil
+}
+
+// NetDCList lists the domain controllers of a domain (empty for the current domain)
+func (c *Client) NetDCList(ctx context.Context, bid, domain string) ([]NetHost, error) {
+	output, err := c.netCommand(ctx, bid, "dclist", "dclist", NetDomainDto{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetHosts(output), nil
+}
+
+// NetDomainTrusts lists the trusts of a domain (empty for the current domain)
+func (c *Client) NetDomainTrusts(ctx context.Context, bid, domain string) ([]NetTrust, error) {
+	output, err := c.netCommand(ctx, bid, "domain_trusts", "domainTrusts", NetDomainDto{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetTrusts(output), nil
+}
+
+// NetGroup lists the domain groups on target, or the members of group when it is set
+func (c *Client) NetGroup(ctx context.Context, bid, target, group string) ([]NetGroupEntry, error) {
+	output, err := c.netCommand(ctx, bid, "group", "group", NetGroupDto{Target: target, GroupName: group})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetGroups(output), nil
+}
+
+// NetLocalGroup lists the local groups on target, or the members of group when it is set
+func (c *Client) NetLocalGroup(ctx context.Context, bid, target, group string) ([]NetGroupEntry, error) {
+	output, err := c.netCommand(ctx, bid, "localgroup", "localGroup", NetGroupDto{Target: target, GroupName: group})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetGroups(output), nil
+}
+
+// NetSessions lists the sessions on target (empty for the beacon's host)
+func (c *Client) NetSessions(ctx context.Context, bid, target string) ([]NetSession, error) {
+	output, err := c.netCommand(ctx, bid, "sessions", "sessions", NetTargetDto{Target: target})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetSessions(output), nil
+}
+
+// NetShare lists the shares on target (empty for the beacon's host)
+func (c *Client) NetShare(ctx context.Context, bid, target string) ([]NetShare, error) {
+	output, err := c.netCommand(ctx, bid, "share", "share", NetTargetDto{Target: target})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetShares(output), nil
+}
+
+// NetUser lists the accounts on target (empty for the beacon's host)
+func (c *Client) NetUser(ctx context.Context, bid, target string) ([]NetUserEntry, error) {
+	output, err := c.netCommand(ctx, bid, "user", "user", NetTargetDto{Target: target})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetUsers(output), nil
+}
+
+// NetView lists the hosts of a domain (empty for the current domain)
+func (c *Client) NetView(ctx context.Context, bid, domain string) ([]NetHost, error) {
+	output, err := c.netCommand(ctx, bid, "view", "view", NetDomainDto{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetHosts(output), nil
+}
+
+// NetLogons lists the users logged on to target (empty for the beacon's host)
+func (c *Client) NetLogons(ctx context.Context, bid, target string) ([]string, error) {
+	output, err := c.netCommand(ctx, bid, "logons", "logons", NetTargetDto{Target: target})
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetLogons(output), nil
+}
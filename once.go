@@ -0,0 +1,187 @@
+package csclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OnceStore records which (beacon, fingerprint) pairs have already been executed
+type OnceStore interface {
+	// Seen reports whether the key has been marked
+	Seen(key string) (bool, error)
+	// Mark records the key as executed
+	Mark(key string) error
+}
+
+// MemoryOnceStore is a non-persistent OnceStore
+type MemoryOnceStore struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+// NewMemoryOnceStore creates an empty in-memory store
+func NewMemoryOnceStore() *MemoryOnceStore {
+	return &MemoryOnceStore{keys: make(map[string]time.Time)}
+}
+
+// Seen reports whether the key has been marked
+func (s *MemoryOnceStore) Seen(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.keys[key]
+	return ok, nil
+}
+
+// Mark records the key as executed
+func (s *MemoryOnceStore) Mark(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = time.Now()
+	return nil
+}
+
+// FileOnceStore is a OnceStore persisted as JSON, so re-running a playbook
+// in a new process still skips steps that already ran
+type FileOnceStore struct {
+	mu   sync.Mutex
+	path string
+	keys map[string]time.Time
+}
+
+// OpenFileOnceStore loads the store at path, creating it on first Mark if it does not exist
+func OpenFileOnceStore(path string) (*FileOnceStore, error) {
+	s := &FileOnceStore{path: path, keys: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read once store: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.keys); err != nil {
+			return nil, fmt.Errorf("failed to parse once store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Seen reports whether the key has been marked
+func (s *FileOnceStore) Seen(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.keys[key]
+	return ok, nil
+}
+
+// Mark records the key as executed and writes the store to disk
+func (s *FileOnceStore) Mark(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = time.Now()
+
+	data, err := json.MarshalIndent(s.keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode once store: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write once store: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes to a temporary file first and renames it over path,
+// so a crash never leaves a truncated file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// CommandFingerprint returns a stable fingerprint of a console command,
+// including the content of any attached files
+func CommandFingerprint(cmd CommandDto) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", cmd.Command, cmd.Arguments)
+
+	names := make([]string, 0, len(cmd.Files))
+	for name := range cmd.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\x00", name, cmd.Files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// OnceGuard skips idempotent setup steps that already ran against a beacon
+type OnceGuard struct {
+	client *Client
+	store  OnceStore
+	feed   *StateChangeFeed
+}
+
+// NewOnceGuard creates a guard backed by the given store
+func NewOnceGuard(client *Client, store OnceStore) *OnceGuard {
+	return &OnceGuard{client: client, store: store}
+}
+
+// SetChangeFeed publishes a "mark" change to feed whenever a step is recorded
+func (g *OnceGuard) SetChangeFeed(feed *StateChangeFeed) {
+	g.feed = feed
+}
+
+// ExecuteOnce runs a console command unless the same command was already
+// submitted to the beacon. skipped is true when the command was not sent.
+func (g *OnceGuard) ExecuteOnce(ctx context.Context, bid string, cmd CommandDto) (resp *AsyncCommandResponse, skipped bool, err error) {
+	return g.Do(ctx, bid, CommandFingerprint(cmd), func(ctx context.Context) (*AsyncCommandResponse, error) {
+		return g.client.ExecuteConsoleCommand(ctx, bid, cmd)
+	})
+}
+
+// Do runs fn unless the (bid, fingerprint) pair was already recorded. This allows
+// guarding any submission (e.g. Upload) with a caller-chosen fingerprint.
+// The pair is only recorded when fn succeeds.
+func (g *OnceGuard) Do(ctx context.Context, bid string, fingerprint string, fn func(ctx context.Context) (*AsyncCommandResponse, error)) (*AsyncCommandResponse, bool, error) {
+	key := bid + ":" + fingerprint
+	seen, err := g.store.Seen(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check once store: %w", err)
+	}
+	if seen {
+		return nil, true, nil
+	}
+
+	resp, err := fn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := g.store.Mark(key); err != nil {
+		return resp, false, fmt.Errorf("command submitted but not recorded: %w", err)
+	}
+	if g.feed != nil {
+		g.feed.Publish(ChangeSourceOnceGuard, "mark", key, resp)
+	}
+	return resp, false, nil
+}
@@ -0,0 +1,160 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// SpawnToDto sets the program post-ex jobs spawn into for one architecture
+type SpawnToDto struct {
+	Arch string `json:"arch"` // "x86" or "x64"
+	Path string `json:"path"` // e.g. %windir%\sysnative\rundll32.exe
+}
+
+// SetSpawnTo sets the programs that post-ex jobs spawn into. An empty path
+// leaves that architecture unchanged; one task is submitted per architecture set.
+func (c *Client) SetSpawnTo(ctx context.Context, bid string, x86, x64 string) ([]*AsyncCommandResponse, error) {
+	if x86 == "" && x64 == "" {
+		return nil, fmt.Errorf("failed to set spawnto: %w", &ValidationError{Field: "spawnto", Reason: "at least one of x86 and x64 is required"})
+	}
+	path, err := beaconPath(bid, "/state/spawnto")
+	if err != nil {
+		return nil, fmt.Errorf("failed to set spawnto: %w", err)
+	}
+
+	var responses []*AsyncCommandResponse
+	for _, req := range []SpawnToDto{{Arch: "x86", Path: x86}, {Arch: "x64", Path: x64}} {
+		if req.Path == "" {
+			continue
+		}
+		var resp AsyncCommandResponse
+		if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+			return responses, fmt.Errorf("failed to set %s spawnto: %w", req.Arch, err)
+		}
+		responses = append(responses, &resp)
+	}
+	return responses, nil
+}
+
+// ResetSpawnTo restores the profile's default spawnto programs
+func (c *Client) ResetSpawnTo(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/spawnto")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset spawnto: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", path, nil, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to reset spawnto: %w", err)
+	}
+	return &resp, nil
+}
+
+// SetBlockDLLs enables or disables blocking of non-Microsoft DLLs in child processes
+func (c *Client) SetBlockDLLs(ctx context.Context, bid string, enabled bool) (*AsyncCommandResponse, error) {
+	suffix := "/state/blockdlls/disable"
+	if enabled {
+		suffix = "/state/blockdlls/enable"
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, suffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set blockdlls: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to set blockdlls: %w", err)
+	}
+	return &resp, nil
+}
+
+// PpidDto sets the parent process for processes the beacon launches
+type PpidDto struct {
+	PID int `json:"pid"`
+}
+
+// SetPPID makes processes launched by the beacon use pid as their parent
+func (c *Client) SetPPID(ctx context.Context, bid string, pid int) (*AsyncCommandResponse, error) {
+	if pid < 0 {
+		return nil, fmt.Errorf("failed to set ppid: %w", &ValidationError{Field: "pid", Value: strconv.Itoa(pid), Reason: "must not be negative"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/ppid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to set ppid: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, PpidDto{PID: pid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to set ppid: %w", err)
+	}
+	return &resp, nil
+}
+
+// ResetPPID makes the beacon launch processes as its own children again
+func (c *Client) ResetPPID(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/ppid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset ppid: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", path, nil, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to reset ppid: %w", err)
+	}
+	return &resp, nil
+}
+
+// SpoofedArgumentsAddDto adds a command to the spoofed arguments list
+type SpoofedArgumentsAddDto struct {
+	Command       string `json:"command"`
+	FakeArguments string `json:"fakeArguments"`
+}
+
+// SpoofedArgumentsRemoveDto removes a command from the spoofed arguments list
+type SpoofedArgumentsRemoveDto struct {
+	Command string `json:"command"`
+}
+
+// Argue spoofs the arguments of command: processes matching it are started with
+// fakeArgs and the real arguments are patched in after launch
+func (c *Client) Argue(ctx context.Context, bid, command, fakeArgs string) (*AsyncCommandResponse, error) {
+	if command == "" || fakeArgs == "" {
+		return nil, fmt.Errorf("failed to add spoofed arguments: %w", &ValidationError{Field: "command", Value: command, Reason: "command and fake arguments are required"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/spoofedArguments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to add spoofed arguments: %w", err)
+	}
+	req := SpoofedArgumentsAddDto{Command: command, FakeArguments: fakeArgs}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to add spoofed arguments: %w", err)
+	}
+	return &resp, nil
+}
+
+// ArgueList lists the commands with spoofed arguments; the list is returned as task output
+func (c *Client) ArgueList(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/spoofedArguments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spoofed arguments: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", path, nil, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to list spoofed arguments: %w", err)
+	}
+	return &resp, nil
+}
+
+// ArgueRemove stops spoofing the arguments of command
+func (c *Client) ArgueRemove(ctx context.Context, bid, command string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/spoofedArguments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove spoofed arguments: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", path, SpoofedArgumentsRemoveDto{Command: command}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to remove spoofed arguments: %w", err)
+	}
+	return &resp, nil
+}
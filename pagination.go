@@ -0,0 +1,194 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// defaultPageSize is used when PageOptions.Limit is not set
+const defaultPageSize = 500
+
+// PageOptions selects a page of a listing.
+// Servers that do not support paging return the full listing, which is then paged client-side.
+type PageOptions struct {
+	Limit  int // Maximum number of items per page (default 500)
+	Offset int // Number of items to skip
+}
+
+// query returns the paging query parameters
+func (o PageOptions) query() url.Values {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(o.limitOrDefault()))
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+func (o PageOptions) limitOrDefault() int {
+	if o.Limit > 0 {
+		return o.Limit
+	}
+	return defaultPageSize
+}
+
+// ListTasksPage retrieves a single page of tasks
+func (c *Client) ListTasksPage(ctx context.Context, opts PageOptions) ([]TaskSummaryDto, error) {
+	return listPage(ctx, opts, c.fetchTasksPage)
+}
+
+// ListBeaconsPage retrieves a single page of beacons
+func (c *Client) ListBeaconsPage(ctx context.Context, opts PageOptions) ([]BeaconDto, error) {
+	return listPage(ctx, opts, c.fetchBeaconsPage)
+}
+
+// TasksIter returns an iterator that transparently fetches task pages
+func (c *Client) TasksIter(ctx context.Context, opts PageOptions) *Iter[TaskSummaryDto] {
+	return newIter(ctx, opts, c.fetchTasksPage)
+}
+
+// BeaconsIter returns an iterator that transparently fetches beacon pages
+func (c *Client) BeaconsIter(ctx context.Context, opts PageOptions) *Iter[BeaconDto] {
+	return newIter(ctx, opts, c.fetchBeaconsPage)
+}
+
+func (c *Client) fetchTasksPage(ctx context.Context, opts PageOptions) ([]TaskSummaryDto, error) {
+	var tasks []TaskSummaryDto
+	if err := c.doRequest(ctx, "GET", withQuery("/api/v1/tasks", opts.query()), nil, &tasks, true); err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (c *Client) fetchBeaconsPage(ctx context.Context, opts PageOptions) ([]BeaconDto, error) {
+	var beacons []BeaconDto
+	if err := c.doRequest(ctx, "GET", withQuery("/api/v1/beacons", opts.query()), nil, &beacons, true); err != nil {
+		return nil, fmt.Errorf("failed to list beacons: %w", err)
+	}
+	return beacons, nil
+}
+
+// listPage fetches a single page, paging client-side if the server ignored paging
+func listPage[T any](ctx context.Context, opts PageOptions, fetch func(context.Context, PageOptions) ([]T, error)) ([]T, error) {
+	items, _, err := fetchPage(ctx, opts, fetch, true)
+	if err != nil {
+		return nil, err
+	}
+	if limit := opts.limitOrDefault(); len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// fetchPage fetches the page selected by opts. A server that ignored the paging
+// parameters returns the full listing: either more than a page, or, for a
+// non-zero offset, a response starting at the same item as the first page
+// (checked with a second request when probe is set). In that case ignored is
+// true and items holds every item from the offset on, which may exceed the limit.
+func fetchPage[T any](ctx context.Context, opts PageOptions, fetch func(context.Context, PageOptions) ([]T, error), probe bool) (items []T, ignored bool, err error) {
+	items, err = fetch(ctx, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	limit := opts.limitOrDefault()
+	ignored = len(items) > limit
+	if !ignored && probe && opts.Offset > 0 && len(items) > 0 {
+		first, err := fetch(ctx, PageOptions{Limit: limit})
+		if err != nil {
+			return nil, false, err
+		}
+		ignored = len(first) > 0 && reflect.DeepEqual(first[0], items[0])
+	}
+	if !ignored {
+		return items, false, nil
+	}
+	if opts.Offset >= len(items) {
+		return nil, true, nil
+	}
+	return items[opts.Offset:], true, nil
+}
+
+// Iter iterates over a paged listing, fetching pages on demand
+//
+//	it := client.TasksIter(ctx, csclient.PageOptions{Limit: 200})
+//	for it.Next() {
+//		task := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iter[T any] struct {
+	ctx   context.Context
+	fetch func(context.Context, PageOptions) ([]T, error)
+	opts  PageOptions
+	page  []T
+	pos   int
+	cur   T
+	done  bool
+	err   error
+	// honored is set once an offset request returned a different first item
+	// than the first page, so later pages need no probe request
+	honored bool
+}
+
+func newIter[T any](ctx context.Context, opts PageOptions, fetch func(context.Context, PageOptions) ([]T, error)) *Iter[T] {
+	opts.Limit = opts.limitOrDefault()
+	return &Iter[T]{ctx: ctx, fetch: fetch, opts: opts}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when the listing is exhausted or an error occurred.
+func (it *Iter[T]) Next() bool {
+	for it.pos >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetchPage()
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+	return true
+}
+
+// fetchPage loads the next page and determines whether more pages follow
+func (it *Iter[T]) fetchPage() {
+	items, ignored, err := fetchPage(it.ctx, it.opts, it.fetch, !it.honored)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	// A server that ignored paging returned everything; serve the rest from memory
+	if ignored || len(items) < it.opts.Limit {
+		it.done = true
+	} else if it.opts.Offset > 0 {
+		it.honored = true
+	}
+
+	it.page = items
+	it.pos = 0
+	it.opts.Offset += len(items)
+	if len(items) == 0 {
+		it.done = true
+	}
+}
+
+// Value returns the current item
+func (it *Iter[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items
+func (it *Iter[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
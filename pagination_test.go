@@ -0,0 +1,108 @@
+package csclient
+
+import (
+	"context"
+	"testing"
+)
+
+// unpagedFetch simulates a server that ignores limit and offset
+func unpagedFetch(total int, calls *int) func(context.Context, PageOptions) ([]int, error) {
+	return func(context.Context, PageOptions) ([]int, error) {
+		*calls++
+		items := make([]int, total)
+		for i := range items {
+			items[i] = i
+		}
+		return items, nil
+	}
+}
+
+// pagedFetch simulates a server that honors limit and offset
+func pagedFetch(total int) func(context.Context, PageOptions) ([]int, error) {
+	return func(_ context.Context, opts PageOptions) ([]int, error) {
+		var items []int
+		for i := opts.Offset; i < total && len(items) < opts.limitOrDefault(); i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestIterUnpagedTotalEqualsLimit(t *testing.T) {
+	calls := 0
+	it := newIter(context.Background(), PageOptions{Limit: 10}, unpagedFetch(10, &calls))
+	items, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 10 {
+		t.Fatalf("got %d items, want 10", len(items))
+	}
+	if calls > 3 {
+		t.Fatalf("fetched %d times", calls)
+	}
+}
+
+func TestIterPaged(t *testing.T) {
+	it := newIter(context.Background(), PageOptions{Limit: 10}, pagedFetch(25))
+	items, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 25 || items[24] != 24 {
+		t.Fatalf("got %v", items)
+	}
+}
+
+func TestIterUnpagedOffset(t *testing.T) {
+	calls := 0
+	it := newIter(context.Background(), PageOptions{Limit: 10, Offset: 5}, unpagedFetch(10, &calls))
+	items, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 5 || items[0] != 5 {
+		t.Fatalf("got %v, want items 5-9", items)
+	}
+}
+
+func TestListPageOffsetBeyondShortResponse(t *testing.T) {
+	calls := 0
+	items, err := listPage(context.Background(), PageOptions{Limit: 100, Offset: 50}, unpagedFetch(40, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("got %d items, want none", len(items))
+	}
+}
+
+func TestListPageOffsetShortResponse(t *testing.T) {
+	calls := 0
+	items, err := listPage(context.Background(), PageOptions{Limit: 100, Offset: 30}, unpagedFetch(40, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 10 || items[0] != 30 {
+		t.Fatalf("got %v, want items 30-39", items)
+	}
+
+	items, err = listPage(context.Background(), PageOptions{Limit: 100, Offset: 30}, pagedFetch(40))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 10 || items[0] != 30 {
+		t.Fatalf("paged server: got %v, want items 30-39", items)
+	}
+}
+
+func TestListPageLimit(t *testing.T) {
+	calls := 0
+	items, err := listPage(context.Background(), PageOptions{Limit: 10, Offset: 5}, unpagedFetch(40, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 10 || items[0] != 5 {
+		t.Fatalf("got %v, want items 5-14", items)
+	}
+}
@@ -0,0 +1,1000 @@
+package csclient
+
+import (
+	"bufio"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// outputLines splits console output into lines without trailing carriage returns
+func outputLines(output string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	return lines
+}
+
+// isStatusLine reports whether a line is a beacon status line ("[*] ...",
+// "[+] received output:") rather than command output
+func isStatusLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"[*]", "[+]", "[-]", "[!]", "received output:"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// DirEntry is a file or directory parsed from "dir" output
+type DirEntry struct {
+	Directory string // Directory the entry was listed in
+	Name      string
+	Size      int64
+	IsDir     bool
+	Modified  string // Date and time as printed by the target
+}
+
+// dirLineRe matches an entry line of cmd.exe "dir" output
+var dirLineRe = regexp.MustCompile(`^(\S+)\s+(\d{1,2}:\d{2}(?:\s?[AaPp][Mm])?)\s+(<DIR>|<JUNCTION>|<SYMLINKD?>|[\d,.\s]+?)\s+(.+)$`)
+
+// ParseDirListing parses the output of cmd.exe "dir" (including "dir /s")
+func ParseDirListing(output string) []DirEntry {
+	var entries []DirEntry
+	directory := ""
+	for _, line := range outputLines(output) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "Directory of ") {
+			directory = strings.TrimSpace(strings.TrimPrefix(trimmed, "Directory of "))
+			continue
+		}
+		m := dirLineRe.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		name := m[4]
+		if name == "." || name == ".." {
+			continue
+		}
+		entry := DirEntry{
+			Directory: directory,
+			Name:      name,
+			Modified:  m[1] + " " + m[2],
+		}
+		if strings.HasPrefix(m[3], "<") {
+			entry.IsDir = true
+			// Junctions and symlinks print their target in brackets after the name
+			if i := strings.LastIndex(name, " ["); i > 0 && strings.HasSuffix(name, "]") {
+				entry.Name = name[:i]
+			}
+		} else {
+			digits := strings.NewReplacer(",", "", ".", "", " ", "", "\u00a0", "").Replace(m[3])
+			entry.Size, _ = strconv.ParseInt(digits, 10, 64)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// parseFixedWidthTable parses a table whose columns are delimited by a line of
+// two or more "=" or "-" runs under the header, as printed by whoami and several net commands.
+// Each row is returned as a map from header name to trimmed cell value.
+func parseFixedWidthTable(lines []string) []map[string]string {
+	rows, _ := nextFixedWidthTable(lines)
+	return rows
+}
+
+// parseFixedWidthTables parses every fixed-width table in the output, in order
+func parseFixedWidthTables(lines []string) [][]map[string]string {
+	var tables [][]map[string]string
+	for len(lines) > 0 {
+		rows, n := nextFixedWidthTable(lines)
+		if n == 0 {
+			break
+		}
+		tables = append(tables, rows)
+		lines = lines[n:]
+	}
+	return tables
+}
+
+// nextFixedWidthTable parses the first fixed-width table in lines and returns
+// its rows and the number of lines consumed (0 if there is no table)
+func nextFixedWidthTable(lines []string) ([]map[string]string, int) {
+	for i := 1; i < len(lines); i++ {
+		sep := lines[i]
+		trimmed := strings.TrimSpace(sep)
+		if trimmed == "" || strings.Trim(trimmed, "=- ") != "" || !strings.ContainsAny(trimmed, "=-") {
+			continue
+		}
+
+		// Column boundaries are the starts of each run of separator characters
+		var starts []int
+		for j := 0; j < len(sep); j++ {
+			if sep[j] != ' ' && (j == 0 || sep[j-1] == ' ') {
+				starts = append(starts, j)
+			}
+		}
+		if len(starts) < 2 {
+			// A single rule is a section underline, not a column separator
+			continue
+		}
+		header := lines[i-1]
+		// Some BOFs pad their columns wider than the separator runs; their
+		// header labels still start where the cells do
+		if labels := headerLabelStarts(header); len(labels) == len(starts) {
+			starts = labels
+		}
+		names := make([]string, len(starts))
+		for k := range starts {
+			names[k] = strings.TrimSpace(sliceColumn(header, starts, k))
+		}
+
+		var rows []map[string]string
+		end := i + 1
+		for ; end < len(lines); end++ {
+			line := lines[end]
+			if strings.TrimSpace(line) == "" {
+				if len(rows) > 0 {
+					break
+				}
+				continue
+			}
+			row := make(map[string]string, len(names))
+			for k, name := range names {
+				row[name] = strings.TrimSpace(sliceColumn(line, starts, k))
+			}
+			rows = append(rows, row)
+		}
+		return rows, end
+	}
+	return nil, 0
+}
+
+// headerLabelStarts returns the start of each label in a table header, where
+// labels are separated by two or more spaces or a tab
+func headerLabelStarts(header string) []int {
+	var starts []int
+	gap := 2
+	for j := 0; j < len(header); j++ {
+		switch header[j] {
+		case ' ':
+			gap++
+		case '\t':
+			gap += 2
+		default:
+			if gap >= 2 {
+				starts = append(starts, j)
+			}
+			gap = 0
+		}
+	}
+	return starts
+}
+
+// sliceColumn returns column k of a fixed-width line; the last column runs to the end
+func sliceColumn(line string, starts []int, k int) string {
+	start := starts[k]
+	if start >= len(line) {
+		return ""
+	}
+	if k+1 < len(starts) && starts[k+1] <= len(line) {
+		return line[start:starts[k+1]]
+	}
+	return line[start:]
+}
+
+// GroupEntry is a group membership parsed from "whoami /groups" output
+type GroupEntry struct {
+	Name       string
+	Type       string
+	SID        string
+	Attributes []string
+}
+
+// ParseWhoamiGroups parses the output of "whoami /groups"
+func ParseWhoamiGroups(output string) []GroupEntry {
+	var groups []GroupEntry
+	for _, row := range parseFixedWidthTable(outputLines(output)) {
+		group := GroupEntry{
+			Name: row["Group Name"],
+			Type: row["Type"],
+			SID:  row["SID"],
+		}
+		for _, attr := range strings.Split(row["Attributes"], ",") {
+			if attr = strings.TrimSpace(attr); attr != "" {
+				group.Attributes = append(group.Attributes, attr)
+			}
+		}
+		if group.Name != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// PrivilegeEntry is a privilege parsed from "whoami /priv" output
+type PrivilegeEntry struct {
+	Name        string
+	Description string
+	State       string // Enabled or Disabled
+}
+
+// WhoamiInfo is the user, groups and privileges parsed from "whoami /all" output
+type WhoamiInfo struct {
+	User       string
+	SID        string
+	Groups     []GroupEntry
+	Privileges []PrivilegeEntry
+}
+
+// ParseWhoamiAll parses the output of "whoami /all" (or a BOF printing the same
+// tables); tables are recognized by their columns, so any of them may be missing
+func ParseWhoamiAll(output string) WhoamiInfo {
+	var info WhoamiInfo
+	lines := outputLines(output)
+	for _, table := range parseFixedWidthTables(lines) {
+		for _, row := range table {
+			switch {
+			case row["Privilege Name"] != "":
+				info.Privileges = append(info.Privileges, PrivilegeEntry{
+					Name:        row["Privilege Name"],
+					Description: row["Description"],
+					State:       row["State"],
+				})
+			case row["Attributes"] != "" || row["Type"] != "":
+				group := GroupEntry{Name: whoamiGroupName(row), Type: row["Type"], SID: row["SID"]}
+				for _, attr := range strings.Split(row["Attributes"], ",") {
+					if attr = strings.TrimSpace(attr); attr != "" {
+						group.Attributes = append(group.Attributes, attr)
+					}
+				}
+				if group.Name != "" {
+					info.Groups = append(info.Groups, group)
+				}
+			}
+		}
+	}
+
+	// The user table may be tab-separated, so read its first row by fields:
+	// the SID is the last field and the user name is everything before it
+	for i := 1; i < len(lines); i++ {
+		header := strings.ToLower(strings.Join(strings.Fields(lines[i-1]), " "))
+		if !strings.HasPrefix(header, "user name") && !strings.HasPrefix(header, "username") {
+			continue
+		}
+		for _, line := range lines[i+1:] {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			if last := fields[len(fields)-1]; strings.HasPrefix(last, "S-1-") {
+				info.SID = last
+				info.User = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), last))
+			}
+			break
+		}
+		break
+	}
+	return info
+}
+
+// whoamiGroupName returns the name cell of a group row: the "Group Name"
+// column or, in builds that label it differently, the first other column by name
+func whoamiGroupName(row map[string]string) string {
+	if name, ok := row["Group Name"]; ok {
+		return name
+	}
+	var keys []string
+	for key := range row {
+		if key != "Type" && key != "SID" && key != "Attributes" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if row[key] != "" {
+			return row[key]
+		}
+	}
+	return ""
+}
+
+// NetworkAdapter is an adapter parsed from "ipconfig" or "ipconfig /all" output
+type NetworkAdapter struct {
+	Name        string
+	MAC         string
+	DHCPEnabled bool
+	IPv4        []string
+	IPv6        []string
+	SubnetMasks []string
+	Gateways    []string
+	DNSServers  []string
+	Properties  map[string][]string // Every property as printed, keyed by label
+}
+
+// ipconfigBOFAdapterRe matches the adapter GUID line of the ipconfig BOF
+var ipconfigBOFAdapterRe = regexp.MustCompile(`^\{[0-9A-Fa-f-]+\}$`)
+
+// macAddressRe matches a MAC address as printed by ipconfig
+var macAddressRe = regexp.MustCompile(`^[0-9A-Fa-f]{2}(?:-[0-9A-Fa-f]{2}){5,7}$`)
+
+// ParseIPConfig parses the output of "ipconfig", "ipconfig /all" or the
+// ipconfig BOF
+func ParseIPConfig(output string) []NetworkAdapter {
+	lines := outputLines(output)
+	for _, line := range lines {
+		if ipconfigBOFAdapterRe.MatchString(line) {
+			return parseIPConfigBOF(lines)
+		}
+	}
+
+	var adapters []NetworkAdapter
+	var current *NetworkAdapter
+	lastKey := ""
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || isStatusLine(line) {
+			continue
+		}
+		// Adapter headers start in column 0 and end with a colon
+		if line[0] != ' ' && line[0] != '\t' {
+			if strings.HasSuffix(strings.TrimSpace(line), ":") {
+				adapters = append(adapters, NetworkAdapter{
+					Name:       strings.TrimSuffix(strings.TrimSpace(line), ":"),
+					Properties: make(map[string][]string),
+				})
+				current = &adapters[len(adapters)-1]
+			} else {
+				current = nil
+			}
+			lastKey = ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, " : ")
+		if !ok {
+			// Continuation line for a multi-valued property (e.g. DNS servers)
+			if lastKey != "" {
+				current.addProperty(lastKey, strings.TrimSpace(line))
+			}
+			continue
+		}
+		lastKey = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(key), ". "))
+		current.addProperty(lastKey, strings.TrimSpace(value))
+	}
+	return adapters
+}
+
+// parseIPConfigBOF parses the ipconfig BOF layout: each adapter's GUID in
+// column 0, its description indented by one tab and its MAC and addresses by
+// two, followed by host-wide "Hostname:", "DNS Suffix:" and "DNS Server:"
+// lines. The DNS servers are recorded on every adapter.
+func parseIPConfigBOF(lines []string) []NetworkAdapter {
+	var adapters []NetworkAdapter
+	var dnsServers []string
+	inDNS := false
+	for _, line := range lines {
+		value := strings.TrimSpace(line)
+		if value == "" || isStatusLine(line) {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			inDNS = false
+			if ipconfigBOFAdapterRe.MatchString(value) {
+				adapters = append(adapters, NetworkAdapter{Name: value, Properties: make(map[string][]string)})
+				continue
+			}
+			if key, server, ok := strings.Cut(value, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "DNS Server") {
+				inDNS = true
+				if server = strings.TrimSpace(server); server != "" {
+					dnsServers = append(dnsServers, server)
+				}
+			}
+			continue
+		}
+		if inDNS {
+			dnsServers = append(dnsServers, value)
+			continue
+		}
+		if len(adapters) == 0 {
+			continue
+		}
+		adapter := &adapters[len(adapters)-1]
+		addr, _, _ := strings.Cut(value, "%")
+		switch ip := net.ParseIP(addr); {
+		case !strings.HasPrefix(line, "\t\t"):
+			adapter.addProperty("Description", value)
+		case macAddressRe.MatchString(value):
+			adapter.addProperty("Physical Address", value)
+		case ip != nil && ip.To4() != nil:
+			adapter.addProperty("IPv4 Address", value)
+		case ip != nil:
+			adapter.addProperty("IPv6 Address", value)
+		}
+	}
+	for i := range adapters {
+		for _, server := range dnsServers {
+			adapters[i].addProperty("DNS Servers", server)
+		}
+	}
+	return adapters
+}
+
+// addProperty records a property and fills the well-known fields
+func (a *NetworkAdapter) addProperty(key, value string) {
+	if value == "" {
+		return
+	}
+	a.Properties[key] = append(a.Properties[key], value)
+
+	// Strip annotations such as "(Preferred)"
+	clean := value
+	if i := strings.Index(clean, "("); i > 0 {
+		clean = strings.TrimSpace(clean[:i])
+	}
+	lower := strings.ToLower(key)
+	switch {
+	case strings.HasPrefix(lower, "physical address"):
+		a.MAC = clean
+	case strings.HasPrefix(lower, "dhcp enabled"):
+		a.DHCPEnabled = strings.EqualFold(clean, "yes")
+	case strings.Contains(lower, "ipv4 address"), lower == "ip address":
+		a.IPv4 = append(a.IPv4, clean)
+	case strings.Contains(lower, "ipv6 address"):
+		a.IPv6 = append(a.IPv6, clean)
+	case strings.HasPrefix(lower, "subnet mask"):
+		a.SubnetMasks = append(a.SubnetMasks, clean)
+	case strings.HasPrefix(lower, "default gateway"):
+		a.Gateways = append(a.Gateways, clean)
+	case strings.HasPrefix(lower, "dns servers"):
+		a.DNSServers = append(a.DNSServers, clean)
+	}
+}
+
+// NetstatEntry is a connection or listening socket parsed from "netstat" output
+type NetstatEntry struct {
+	Proto   string
+	Local   string
+	Foreign string
+	State   string // Empty for UDP
+	PID     int    // Only present with "netstat -o"
+}
+
+// netstatGluedRe matches an IPv6 local address that filled its column and
+// runs into the foreign address
+var netstatGluedRe = regexp.MustCompile(`^(\[[^\]]*\]:\d+)(\*:\*|\[.*)$`)
+
+// ParseNetstat parses the output of "netstat -an", "netstat -ano" or the
+// netstat BOF
+func ParseNetstat(output string) []NetstatEntry {
+	var entries []NetstatEntry
+	for _, line := range outputLines(output) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		proto := strings.ToUpper(fields[0])
+		if !strings.HasPrefix(proto, "TCP") && !strings.HasPrefix(proto, "UDP") {
+			continue
+		}
+		if m := netstatGluedRe.FindStringSubmatch(fields[1]); m != nil {
+			fields = append([]string{fields[0], m[1], m[2]}, fields[2:]...)
+		}
+		if len(fields) < 3 {
+			continue
+		}
+		entry := NetstatEntry{Proto: proto, Local: fields[1], Foreign: fields[2]}
+		rest := fields[3:]
+		if strings.HasPrefix(proto, "TCP") && len(rest) > 0 {
+			entry.State = rest[0]
+			rest = rest[1:]
+		}
+		if len(rest) > 0 {
+			entry.PID, _ = strconv.Atoi(rest[0])
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// HashEntry is an account parsed from hashdump (pwdump format) output
+type HashEntry struct {
+	User string
+	RID  int
+	LM   string
+	NTLM string
+}
+
+// hashdumpLineRe matches user:rid:lm:ntlm::: lines
+var hashdumpLineRe = regexp.MustCompile(`^(.+?):(\d+):([0-9A-Fa-f]{32}):([0-9A-Fa-f]{32}):::?\s*$`)
+
+// ParseHashdump parses the output of hashdump into user:rid:lm:ntlm entries
+func ParseHashdump(output string) []HashEntry {
+	var entries []HashEntry
+	for _, line := range outputLines(output) {
+		m := hashdumpLineRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		rid, _ := strconv.Atoi(m[2])
+		entries = append(entries, HashEntry{
+			User: m[1],
+			RID:  rid,
+			LM:   strings.ToLower(m[3]),
+			NTLM: strings.ToLower(m[4]),
+		})
+	}
+	return entries
+}
+
+// LogonCredential is a credential parsed from mimikatz sekurlsa::logonpasswords output
+type LogonCredential struct {
+	AuthID      string // Authentication Id of the logon session
+	Session     string // Logon type, e.g. "Interactive from 1"
+	LogonUser   string // User Name of the logon session
+	LogonDomain string
+	LogonServer string
+	SID         string
+	Package     string // msv, wdigest, kerberos, ...
+	Username    string
+	Domain      string
+	NTLM        string
+	SHA1        string
+	Password    string
+}
+
+// mimikatzPackageRe matches a package header line such as "	msv :"
+var mimikatzPackageRe = regexp.MustCompile(`^\s+([a-z][a-z0-9]*)\s*:\s*$`)
+
+// mimikatzFieldRe matches a credential field such as "	 * NTLM     : 31d6..."
+var mimikatzFieldRe = regexp.MustCompile(`^\s+\*\s+([A-Za-z0-9 ]+?)\s*:\s?(.*)$`)
+
+// ParseLogonPasswords parses mimikatz sekurlsa::logonpasswords output into one
+// record per package credential holding a hash or password. "(null)" values are dropped.
+func ParseLogonPasswords(output string) []LogonCredential {
+	var creds []LogonCredential
+	var session, current LogonCredential
+	inCred := false
+
+	flush := func() {
+		if inCred && (current.NTLM != "" || current.Password != "") {
+			creds = append(creds, current)
+		}
+		inCred = false
+	}
+
+	for _, line := range outputLines(output) {
+		if m := mimikatzFieldRe.FindStringSubmatch(line); m != nil {
+			key, value := strings.ToLower(m[1]), strings.TrimSpace(m[2])
+			if value == "(null)" {
+				value = ""
+			}
+			if key == "username" {
+				flush()
+				current = session
+				inCred = true
+			}
+			if !inCred {
+				continue
+			}
+			switch key {
+			case "username":
+				current.Username = value
+			case "domain":
+				current.Domain = value
+			case "ntlm":
+				current.NTLM = strings.ToLower(value)
+			case "sha1":
+				current.SHA1 = strings.ToLower(value)
+			case "password":
+				current.Password = value
+			}
+			continue
+		}
+		if m := mimikatzPackageRe.FindStringSubmatch(line); m != nil {
+			flush()
+			session.Package = m[1]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, " : ")
+		if !ok || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Authentication Id":
+			flush()
+			session = LogonCredential{AuthID: value}
+		case "Session":
+			session.Session = value
+		case "User Name":
+			session.LogonUser = value
+		case "Domain":
+			session.LogonDomain = value
+		case "Logon Server":
+			session.LogonServer = value
+		case "SID":
+			session.SID = value
+		}
+	}
+	flush()
+	return creds
+}
+
+// PortScanResult is an open port parsed from portscan output
+type PortScanResult struct {
+	Host    string
+	Port    int
+	Service string // Well-known service name, "smb" for SMB banners
+	Banner  string // Banner or SMB details printed in parentheses, if any
+	// SMB details reported for port 445
+	NetBIOSName string
+	Domain      string
+	OSVersion   string
+}
+
+// portScanLineRe matches "host:port" with an optional "(banner)"
+var portScanLineRe = regexp.MustCompile(`^(\S+?):(\d{1,5})(?:\s+\((.*)\))?\s*$`)
+
+// ParsePortScan parses the output of portscan into one record per open port
+func ParsePortScan(output string) []PortScanResult {
+	var results []PortScanResult
+	for _, line := range outputLines(output) {
+		m := portScanLineRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		port, err := strconv.Atoi(m[2])
+		if err != nil || port < 1 || port > 65535 {
+			continue
+		}
+		r := PortScanResult{Host: m[1], Port: port, Banner: m[3], Service: PortServiceName(port)}
+		if strings.HasPrefix(r.Banner, "platform:") {
+			r.Service = "smb"
+			fields := strings.Fields(r.Banner)
+			for i := 0; i+1 < len(fields); i += 2 {
+				switch fields[i] {
+				case "version:":
+					r.OSVersion = fields[i+1]
+				case "name:":
+					r.NetBIOSName = fields[i+1]
+				case "domain:":
+					r.Domain = fields[i+1]
+				}
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// driveRe matches a drive letter such as "C:" or "C:\"
+var driveRe = regexp.MustCompile(`^([A-Za-z]):\\?$`)
+
+// ParseDrives parses the drive letters printed by "drives", returning them
+// upper-cased and without a trailing backslash ("C:")
+func ParseDrives(output string) []string {
+	var drives []string
+	seen := make(map[string]bool)
+	for _, line := range outputLines(output) {
+		for _, field := range strings.Fields(line) {
+			m := driveRe.FindStringSubmatch(field)
+			if m == nil {
+				continue
+			}
+			drive := strings.ToUpper(m[1]) + ":"
+			if !seen[drive] {
+				seen[drive] = true
+				drives = append(drives, drive)
+			}
+		}
+	}
+	return drives
+}
+
+// NetHost is a host parsed from "net computers", "net dclist" or "net view" output
+type NetHost struct {
+	Name     string
+	Address  string
+	Platform string
+	Version  string
+	Type     string
+	Comment  string
+}
+
+// ParseNetHosts parses the host table printed by "net computers", "net dclist" and "net view"
+func ParseNetHosts(output string) []NetHost {
+	var hosts []NetHost
+	for _, row := range parseFixedWidthTable(outputLines(output)) {
+		host := NetHost{
+			Name:     row["Server Name"],
+			Address:  row["IP Address"],
+			Platform: row["Platform"],
+			Version:  row["Version"],
+			Type:     row["Type"],
+			Comment:  row["Comment"],
+		}
+		if host.Name != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// NetTrust is a domain trust parsed from "net domain_trusts" output
+type NetTrust struct {
+	NetBIOSName string
+	DNSName     string
+	Flags       []string // e.g. "Primary Domain", "Direct Outbound", "Attr: 0x20"
+}
+
+var (
+	netTrustLineRe = regexp.MustCompile(`^\d+:\s+(\S+)\s+(\S+)(.*)$`)
+	netTrustFlagRe = regexp.MustCompile(`\(([^)]*)\)`)
+)
+
+// ParseNetTrusts parses the numbered trust list printed by "net domain_trusts"
+func ParseNetTrusts(output string) []NetTrust {
+	var trusts []NetTrust
+	for _, line := range outputLines(output) {
+		m := netTrustLineRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		trust := NetTrust{NetBIOSName: m[1], DNSName: m[2]}
+		for _, flag := range netTrustFlagRe.FindAllStringSubmatch(m[3], -1) {
+			if f := strings.TrimSpace(flag[1]); f != "" {
+				trust.Flags = append(trust.Flags, f)
+			}
+		}
+		trusts = append(trusts, trust)
+	}
+	return trusts
+}
+
+// NetGroupEntry is a group, or a group member, parsed from "net group" or "net localgroup" output
+type NetGroupEntry struct {
+	Name    string
+	Comment string
+}
+
+// ParseNetGroups parses "net group" and "net localgroup" output. Without a
+// group name the commands list groups and their comments; with one they list
+// the group's members, which are returned with an empty comment.
+func ParseNetGroups(output string) []NetGroupEntry {
+	var entries []NetGroupEntry
+	if rows := parseFixedWidthTable(outputLines(output)); rows != nil {
+		for _, row := range rows {
+			if row["Name"] != "" {
+				entries = append(entries, NetGroupEntry{Name: row["Name"], Comment: row["Comment"]})
+			}
+		}
+		return entries
+	}
+	for _, item := range netListItems(output) {
+		entries = append(entries, NetGroupEntry{Name: item})
+	}
+	return entries
+}
+
+// NetSession is a session parsed from "net sessions" output
+type NetSession struct {
+	Client string
+	User   string
+	Active int // Seconds the session has been active
+	Idle   int // Seconds the session has been idle
+}
+
+// ParseNetSessions parses the session table printed by "net sessions"
+func ParseNetSessions(output string) []NetSession {
+	var sessions []NetSession
+	for _, row := range parseFixedWidthTable(outputLines(output)) {
+		session := NetSession{Client: row["Client"], User: row["User name"]}
+		session.Active, _ = strconv.Atoi(row["Active"])
+		session.Idle, _ = strconv.Atoi(row["Idle"])
+		if session.Client != "" || session.User != "" {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// NetShare is a share parsed from "net share" output
+type NetShare struct {
+	Name    string
+	Comment string
+}
+
+// ParseNetShares parses the share table printed by "net share"
+func ParseNetShares(output string) []NetShare {
+	var shares []NetShare
+	for _, row := range parseFixedWidthTable(outputLines(output)) {
+		if row["Share name"] != "" {
+			shares = append(shares, NetShare{Name: row["Share name"], Comment: row["Comment"]})
+		}
+	}
+	return shares
+}
+
+// NetUserEntry is an account parsed from "net user" output
+type NetUserEntry struct {
+	Name  string
+	Admin bool // Marked "(admin)" by the beacon
+}
+
+// ParseNetUsers parses the account list printed by "net user"
+func ParseNetUsers(output string) []NetUserEntry {
+	var users []NetUserEntry
+	for _, item := range netListItems(output) {
+		name, admin := strings.CutSuffix(item, "(admin)")
+		users = append(users, NetUserEntry{Name: strings.TrimSpace(name), Admin: admin})
+	}
+	return users
+}
+
+// ParseNetLogons parses the logged on users printed by "net logons"
+func ParseNetLogons(output string) []string {
+	return netListItems(output)
+}
+
+// netListItems returns the entries of a single-column net listing: the
+// non-empty lines after its column rule, or after its "...:" title line
+// when there is no rule. Beacon status lines ("[*] ...") are skipped.
+func netListItems(output string) []string {
+	lines := outputLines(output)
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && strings.Trim(trimmed, "-") == "" {
+			start = i + 1
+			break
+		}
+		if start < 0 && strings.HasSuffix(trimmed, ":") {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	var items []string
+	for _, line := range lines[start:] {
+		item := strings.TrimSpace(line)
+		if item == "" || strings.HasPrefix(item, "[") {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// ServiceStatus is a service parsed from "sc query", "sc queryex", "sc start" or "sc stop" output
+type ServiceStatus struct {
+	Name        string
+	DisplayName string
+	Type        string // e.g. WIN32_OWN_PROCESS
+	State       string // e.g. RUNNING, STOPPED, START_PENDING
+	PID         int    // Reported by queryex and start
+	ExitCode    int    // WIN32_EXIT_CODE
+}
+
+// ParseServiceStatus parses the SERVICE_NAME blocks printed by sc.exe
+func ParseServiceStatus(output string) []ServiceStatus {
+	var services []ServiceStatus
+	var current *ServiceStatus
+	for _, line := range outputLines(output) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "SERVICE_NAME" {
+			services = append(services, ServiceStatus{Name: value})
+			current = &services[len(services)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		// Numeric fields print the code first: "STATE : 4  RUNNING"
+		fields := strings.Fields(value)
+		switch key {
+		case "DISPLAY_NAME":
+			current.DisplayName = value
+		case "TYPE":
+			if len(fields) > 1 {
+				current.Type = fields[1]
+			}
+		case "STATE":
+			if len(fields) > 1 {
+				current.State = fields[1]
+			}
+		case "PID":
+			current.PID, _ = strconv.Atoi(value)
+		case "WIN32_EXIT_CODE":
+			if len(fields) > 0 {
+				current.ExitCode, _ = strconv.Atoi(fields[0])
+			}
+		}
+	}
+	return services
+}
+
+// ParseEnvironment parses "NAME=value" lines, as printed by "set" or an env BOF.
+// Hidden per-drive variables such as "=C:" keep their leading "=".
+func ParseEnvironment(output string) map[string]string {
+	env := make(map[string]string)
+	for _, line := range outputLines(output) {
+		if isStatusLine(line) {
+			continue
+		}
+		prefix := ""
+		if strings.HasPrefix(line, "=") {
+			prefix, line = "=", line[1:]
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			continue
+		}
+		env[prefix+name] = value
+	}
+	return env
+}
+
+// DNSCacheEntry is a record parsed from DNS cache listings
+type DNSCacheEntry struct {
+	Name string
+	Type string // Record type (A, AAAA, CNAME...) when printed
+	Data string // Record data (address, alias target...) when printed
+}
+
+var (
+	dnsNameRe = regexp.MustCompile(`^[A-Za-z0-9_*][A-Za-z0-9_.*-]*$`)
+	dnsTypeRe = regexp.MustCompile(`^(?:TYPE)?\d+$`)
+)
+
+// dnsRecordTypes are the record type mnemonics printed in DNS cache listings
+var dnsRecordTypes = map[string]bool{
+	"A": true, "NS": true, "CNAME": true, "SOA": true, "PTR": true, "MX": true, "TXT": true,
+	"AAAA": true, "SRV": true, "NAPTR": true, "DNAME": true, "DS": true, "RRSIG": true,
+	"NSEC": true, "DNSKEY": true, "SVCB": true, "HTTPS": true, "CAA": true, "ANY": true,
+	"WINS": true, "WINSR": true,
+}
+
+// ParseDNSCache parses a DNS cache listing with one "name [type [data]]" entry
+// per line, as printed by the listdns BOF. Header, rule and status lines and
+// lines whose second field is not a record type are skipped.
+func ParseDNSCache(output string) []DNSCacheEntry {
+	var entries []DNSCacheEntry
+	for _, line := range outputLines(output) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || isStatusLine(line) || strings.EqualFold(fields[0], "name") {
+			continue
+		}
+		name := strings.TrimSuffix(fields[0], ".")
+		if !dnsNameRe.MatchString(name) {
+			continue
+		}
+		entry := DNSCacheEntry{Name: name}
+		if len(fields) > 1 {
+			if !dnsRecordTypes[strings.ToUpper(fields[1])] && !dnsTypeRe.MatchString(fields[1]) {
+				continue
+			}
+			entry.Type = fields[1]
+			entry.Data = strings.Join(fields[2:], " ")
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,604 @@
+package csclient
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The fixtures below are console output captured from Windows targets and
+// beacon commands, with lines joined by "\n"
+
+// crlf converts a fixture to the CRLF line endings cmd.exe prints
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+const dirOutput = ` Volume in drive C has no label.
+ Volume Serial Number is 1A2B-3C4D
+
+ Directory of C:\Users\jdoe
+
+03/14/2024  09:12 AM    <DIR>          .
+03/14/2024  09:12 AM    <DIR>          ..
+02/01/2024  04:55 PM    <DIR>          Desktop
+01/22/2024  11:03 AM             1,024 notes.txt
+11/05/2023  08:30 PM    <JUNCTION>     My Documents [C:\Users\jdoe\Documents]
+12/30/2023  02:17 PM        12,345,678 backup 2023.zip
+               2 File(s)     12,346,702 bytes
+
+ Directory of C:\Users\jdoe\Desktop
+
+03/14/2024  09:12 AM    <DIR>          .
+03/14/2024  09:12 AM    <DIR>          ..
+03/01/2024  10:45 AM               512 todo.txt
+               1 File(s)            512 bytes
+
+     Total Files Listed:
+               3 File(s)     12,347,214 bytes
+               5 Dir(s)  52,345,671,680 bytes free
+`
+
+const whoamiGroupsOutput = `
+GROUP INFORMATION
+-----------------
+
+Group Name                             Type             SID          Attributes
+====================================== ================ ============ ==================================================
+Everyone                               Well-known group S-1-1-0      Mandatory group, Enabled by default, Enabled group
+BUILTIN\Administrators                 Alias            S-1-5-32-544 Group used for deny only
+NT AUTHORITY\INTERACTIVE               Well-known group S-1-5-4      Mandatory group, Enabled by default, Enabled group
+Mandatory Label\Medium Mandatory Level Label            S-1-16-8192
+`
+
+const whoamiAllOutput = `
+USER INFORMATION
+----------------
+
+User Name SID
+========= =============================================
+corp\jdoe S-1-5-21-1004336348-1177238915-682003330-1104
+
+
+GROUP INFORMATION
+-----------------
+
+Group Name                           Type             SID                                          Attributes
+==================================== ================ ============================================ ==================================================
+Everyone                             Well-known group S-1-1-0                                      Mandatory group, Enabled by default, Enabled group
+CORP\Domain Admins                   Group            S-1-5-21-1004336348-1177238915-682003330-512 Mandatory group, Enabled by default, Enabled group
+Mandatory Label\High Mandatory Level Label            S-1-16-12288
+
+
+PRIVILEGES INFORMATION
+----------------------
+
+Privilege Name          Description              State
+======================= ======================== ========
+SeShutdownPrivilege     Shut down the system     Disabled
+SeChangeNotifyPrivilege Bypass traverse checking Enabled
+SeDebugPrivilege        Debug programs           Enabled
+
+
+USER CLAIMS INFORMATION
+-----------------------
+
+User claims unknown.
+
+Kerberos support for Dynamic Access Control on this device has been disabled.
+`
+
+// whoamiExtraColumnOutput is a group table with a column besides the name,
+// type, SID and attributes, as printed by some whoami BOFs
+const whoamiExtraColumnOutput = `Group Name           Domain  Type  SID          Attributes
+==================== ======= ===== ============ =============
+Backup Operators     BUILTIN Alias S-1-5-32-551 Enabled group
+Remote Desktop Users BUILTIN Alias S-1-5-32-555 Enabled group
+`
+
+const ipconfigAllOutput = `
+Windows IP Configuration
+
+   Host Name . . . . . . . . . . . . : WS01
+   Primary Dns Suffix  . . . . . . . : corp.local
+   Node Type . . . . . . . . . . . . : Hybrid
+   IP Routing Enabled. . . . . . . . : No
+   WINS Proxy Enabled. . . . . . . . : No
+   DNS Suffix Search List. . . . . . : corp.local
+
+Ethernet adapter Ethernet0:
+
+   Connection-specific DNS Suffix  . : corp.local
+   Description . . . . . . . . . . . : Intel(R) 82574L Gigabit Network Connection
+   Physical Address. . . . . . . . . : 00-0C-29-AB-CD-EF
+   DHCP Enabled. . . . . . . . . . . : Yes
+   Autoconfiguration Enabled . . . . : Yes
+   Link-local IPv6 Address . . . . . : fe80::1c2d:3e4f:5a6b:7c8d%12(Preferred)
+   IPv4 Address. . . . . . . . . . . : 10.0.0.15(Preferred)
+   Subnet Mask . . . . . . . . . . . : 255.255.255.0
+   Lease Obtained. . . . . . . . . . : Thursday, March 14, 2024 9:01:02 AM
+   Default Gateway . . . . . . . . . : 10.0.0.1
+   DHCP Server . . . . . . . . . . . : 10.0.0.10
+   DNS Servers . . . . . . . . . . . : 10.0.0.10
+                                       10.0.0.11
+   NetBIOS over Tcpip. . . . . . . . : Enabled
+
+Tunnel adapter isatap.corp.local:
+
+   Media State . . . . . . . . . . . : Media disconnected
+   Connection-specific DNS Suffix  . : corp.local
+   Description . . . . . . . . . . . : Microsoft ISATAP Adapter
+   Physical Address. . . . . . . . . : 00-00-00-00-00-00-00-E0
+   DHCP Enabled. . . . . . . . . . . : No
+`
+
+const netstatOutput = `
+Active Connections
+
+  Proto  Local Address          Foreign Address        State           PID
+  TCP    0.0.0.0:135            0.0.0.0:0              LISTENING       912
+  TCP    10.0.0.15:49712        10.0.0.10:445          ESTABLISHED     4
+  TCP    [::]:135               [::]:0                 LISTENING       912
+  UDP    0.0.0.0:123            *:*                                    1288
+  UDP    [::1]:1900             *:*                                    3344
+`
+
+const hashdumpOutput = `[*] Tasked beacon to dump hashes
+[+] host called home, sent: 82501 bytes
+[+] received password hashes:
+Administrator:500:aad3b435b51404eeaad3b435b51404ee:FC525C9683E8FE067095BA2DDC971889:::
+Guest:501:aad3b435b51404eeaad3b435b51404ee:31d6cfe0d16ae931b73c59d7e0c089c0:::
+DefaultAccount:503:aad3b435b51404eeaad3b435b51404ee:31d6cfe0d16ae931b73c59d7e0c089c0:::
+`
+
+const logonPasswordsOutput = "\n" +
+	"Authentication Id : 0 ; 996 (00000000:000003e4)\n" +
+	"Session           : Service from 0\n" +
+	"User Name         : WS01$\n" +
+	"Domain            : CORP\n" +
+	"Logon Server      : (null)\n" +
+	"Logon Time        : 3/14/2024 9:01:12 AM\n" +
+	"SID               : S-1-5-20\n" +
+	"\tmsv :\t\n" +
+	"\t [00000003] Primary\n" +
+	"\t * Username : WS01$\n" +
+	"\t * Domain   : CORP\n" +
+	"\t * NTLM     : 0123456789ABCDEF0123456789ABCDEF\n" +
+	"\t * SHA1     : 0123456789abcdef0123456789abcdef01234567\n" +
+	"\ttspkg :\t\n" +
+	"\twdigest :\t\n" +
+	"\t * Username : WS01$\n" +
+	"\t * Domain   : CORP\n" +
+	"\t * Password : (null)\n" +
+	"\tkerberos :\t\n" +
+	"\t * Username : ws01$\n" +
+	"\t * Domain   : corp.local\n" +
+	"\t * Password : (null)\n" +
+	"\n" +
+	"Authentication Id : 0 ; 274831 (00000000:0004318f)\n" +
+	"Session           : Interactive from 1\n" +
+	"User Name         : jdoe\n" +
+	"Domain            : CORP\n" +
+	"Logon Server      : DC01\n" +
+	"Logon Time        : 3/14/2024 9:05:44 AM\n" +
+	"SID               : S-1-5-21-1004336348-1177238915-682003330-1104\n" +
+	"\tmsv :\t\n" +
+	"\t [00000003] Primary\n" +
+	"\t * Username : jdoe\n" +
+	"\t * Domain   : CORP\n" +
+	"\t * NTLM     : 8846f7eaee8fb117ad06bdd830b7586c\n" +
+	"\t * SHA1     : e8f97fba9104d1ea5047948e6dfb67facd9f5b73\n" +
+	"\t * DPAPI    : 5b9c7f1c8e1d4e0f9a3b2c1d0e9f8a7b\n" +
+	"\twdigest :\t\n" +
+	"\t * Username : jdoe\n" +
+	"\t * Domain   : CORP\n" +
+	"\t * Password : Summer2024!\n" +
+	"\tkerberos :\t\n" +
+	"\t * Username : jdoe\n" +
+	"\t * Domain   : CORP.LOCAL\n" +
+	"\t * Password : (null)\n"
+
+const portScanOutput = `(ICMP) Target '10.0.0.10' is alive. [read 8 bytes]
+10.0.0.10:445 (platform: 500 version: 10.0 name: DC01 domain: CORP)
+10.0.0.10:88
+10.0.0.20:22 (SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.6)
+10.0.0.15:3389
+
+Scanner module is complete
+`
+
+const drivesOutput = `[*] Tasked beacon to list drives
+[+] host called home, sent: 8 bytes
+[+] received output:
+C:\
+D:\
+c:\
+`
+
+const netHostsOutput = `List of hosts:
+
+ Server Name IP Address Platform Version Type Comment
+ ----------- ---------- -------- ------- ---- ----------
+ DC01        10.0.0.10  500      10.0    PDC  Primary DC
+ WS01        10.0.0.15  500      10.0
+`
+
+const netTrustsOutput = `List of domain trusts:
+
+ 0: CORP corp.local (Forest tree root) (Primary Domain) (Native) (Direct Outbound) (Direct Inbound) (Attr: 0x8)
+ 1: DEV dev.corp.local (Forest: 0) (Native) (Direct Outbound) (Direct Inbound) (Attr: 0x20)
+`
+
+const netGroupsOutput = `Groups on \\DC01:
+
+Name            Comment
+--------------- ---------------------------------------
+Domain Admins   Designated administrators of the domain
+Domain Users    All domain users
+Protected Users
+`
+
+const netGroupMembersOutput = `Members of Domain Admins:
+
+Administrator
+jdoe
+`
+
+const netSessionsOutput = `Sessions for \\DC01:
+
+Client      User name     Active Idle
+----------- ------------- ------ ----
+\\10.0.0.15 jdoe          120    5
+\\[::1]     Administrator 3600   0
+`
+
+const netSharesOutput = `Shares at \\DC01:
+
+ Share name Comment
+ ---------- ------------------
+ ADMIN$     Remote Admin
+ C$         Default share
+ IPC$       Remote IPC
+ NETLOGON   Logon server share
+`
+
+const netUsersOutput = `Users for \\DC01:
+
+Administrator (admin)
+Guest
+jdoe
+krbtgt
+`
+
+const netLogonsOutput = `Logged on users at \\WS01:
+
+CORP\jdoe
+CORP\WS01$
+`
+
+const scQueryexOutput = `
+SERVICE_NAME: Spooler
+DISPLAY_NAME: Print Spooler
+        TYPE               : 110  WIN32_OWN_PROCESS  (interactive)
+        STATE              : 4  RUNNING
+                                (STOPPABLE, NOT_PAUSABLE, IGNORES_SHUTDOWN)
+        WIN32_EXIT_CODE    : 0  (0x0)
+        SERVICE_EXIT_CODE  : 0  (0x0)
+        CHECKPOINT         : 0x0
+        WAIT_HINT          : 0x0
+        PID                : 2184
+        FLAGS              :
+
+SERVICE_NAME: wuauserv
+DISPLAY_NAME: Windows Update
+        TYPE               : 20  WIN32_SHARE_PROCESS
+        STATE              : 1  STOPPED
+        WIN32_EXIT_CODE    : 1077  (0x435)
+        SERVICE_EXIT_CODE  : 0  (0x0)
+        CHECKPOINT         : 0x0
+        WAIT_HINT          : 0x0
+        PID                : 0
+        FLAGS              :
+`
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() interface{}
+		want interface{}
+	}{
+		{"dir", func() interface{} { return ParseDirListing(crlf(dirOutput)) }, []DirEntry{
+			{Directory: `C:\Users\jdoe`, Name: "Desktop", IsDir: true, Modified: "02/01/2024 04:55 PM"},
+			{Directory: `C:\Users\jdoe`, Name: "notes.txt", Size: 1024, Modified: "01/22/2024 11:03 AM"},
+			{Directory: `C:\Users\jdoe`, Name: "My Documents", IsDir: true, Modified: "11/05/2023 08:30 PM"},
+			{Directory: `C:\Users\jdoe`, Name: "backup 2023.zip", Size: 12345678, Modified: "12/30/2023 02:17 PM"},
+			{Directory: `C:\Users\jdoe\Desktop`, Name: "todo.txt", Size: 512, Modified: "03/01/2024 10:45 AM"},
+		}},
+		{"whoami groups", func() interface{} { return ParseWhoamiGroups(crlf(whoamiGroupsOutput)) }, []GroupEntry{
+			{Name: "Everyone", Type: "Well-known group", SID: "S-1-1-0", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+			{Name: `BUILTIN\Administrators`, Type: "Alias", SID: "S-1-5-32-544", Attributes: []string{"Group used for deny only"}},
+			{Name: `NT AUTHORITY\INTERACTIVE`, Type: "Well-known group", SID: "S-1-5-4", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+			{Name: `Mandatory Label\Medium Mandatory Level`, Type: "Label", SID: "S-1-16-8192"},
+		}},
+		{"whoami all", func() interface{} { return ParseWhoamiAll(crlf(whoamiAllOutput)) }, WhoamiInfo{
+			User: `corp\jdoe`,
+			SID:  "S-1-5-21-1004336348-1177238915-682003330-1104",
+			Groups: []GroupEntry{
+				{Name: "Everyone", Type: "Well-known group", SID: "S-1-1-0", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+				{Name: `CORP\Domain Admins`, Type: "Group", SID: "S-1-5-21-1004336348-1177238915-682003330-512", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+				{Name: `Mandatory Label\High Mandatory Level`, Type: "Label", SID: "S-1-16-12288"},
+			},
+			Privileges: []PrivilegeEntry{
+				{Name: "SeShutdownPrivilege", Description: "Shut down the system", State: "Disabled"},
+				{Name: "SeChangeNotifyPrivilege", Description: "Bypass traverse checking", State: "Enabled"},
+				{Name: "SeDebugPrivilege", Description: "Debug programs", State: "Enabled"},
+			},
+		}},
+		{"whoami extra column", func() interface{} { return ParseWhoamiAll(whoamiExtraColumnOutput).Groups }, []GroupEntry{
+			{Name: "Backup Operators", Type: "Alias", SID: "S-1-5-32-551", Attributes: []string{"Enabled group"}},
+			{Name: "Remote Desktop Users", Type: "Alias", SID: "S-1-5-32-555", Attributes: []string{"Enabled group"}},
+		}},
+		{"ipconfig all", func() interface{} {
+			adapters := ParseIPConfig(crlf(ipconfigAllOutput))
+			for i := range adapters {
+				adapters[i].Properties = nil
+			}
+			return adapters
+		}, []NetworkAdapter{
+			{
+				Name:        "Ethernet adapter Ethernet0",
+				MAC:         "00-0C-29-AB-CD-EF",
+				DHCPEnabled: true,
+				IPv4:        []string{"10.0.0.15"},
+				IPv6:        []string{"fe80::1c2d:3e4f:5a6b:7c8d%12"},
+				SubnetMasks: []string{"255.255.255.0"},
+				Gateways:    []string{"10.0.0.1"},
+				DNSServers:  []string{"10.0.0.10", "10.0.0.11"},
+			},
+			{Name: "Tunnel adapter isatap.corp.local", MAC: "00-00-00-00-00-00-00-E0"},
+		}},
+		{"ipconfig properties", func() interface{} { return ParseIPConfig(ipconfigAllOutput)[0].Properties["Description"] }, []string{"Intel(R) 82574L Gigabit Network Connection"}},
+		{"netstat", func() interface{} { return ParseNetstat(crlf(netstatOutput)) }, []NetstatEntry{
+			{Proto: "TCP", Local: "0.0.0.0:135", Foreign: "0.0.0.0:0", State: "LISTENING", PID: 912},
+			{Proto: "TCP", Local: "10.0.0.15:49712", Foreign: "10.0.0.10:445", State: "ESTABLISHED", PID: 4},
+			{Proto: "TCP", Local: "[::]:135", Foreign: "[::]:0", State: "LISTENING", PID: 912},
+			{Proto: "UDP", Local: "0.0.0.0:123", Foreign: "*:*", PID: 1288},
+			{Proto: "UDP", Local: "[::1]:1900", Foreign: "*:*", PID: 3344},
+		}},
+		{"hashdump", func() interface{} { return ParseHashdump(hashdumpOutput) }, []HashEntry{
+			{User: "Administrator", RID: 500, LM: "aad3b435b51404eeaad3b435b51404ee", NTLM: "fc525c9683e8fe067095ba2ddc971889"},
+			{User: "Guest", RID: 501, LM: "aad3b435b51404eeaad3b435b51404ee", NTLM: "31d6cfe0d16ae931b73c59d7e0c089c0"},
+			{User: "DefaultAccount", RID: 503, LM: "aad3b435b51404eeaad3b435b51404ee", NTLM: "31d6cfe0d16ae931b73c59d7e0c089c0"},
+		}},
+		{"logonpasswords", func() interface{} { return ParseLogonPasswords(crlf(logonPasswordsOutput)) }, []LogonCredential{
+			{
+				AuthID: "0 ; 996 (00000000:000003e4)", Session: "Service from 0", LogonUser: "WS01$", LogonDomain: "CORP",
+				LogonServer: "(null)", SID: "S-1-5-20", Package: "msv", Username: "WS01$", Domain: "CORP",
+				NTLM: "0123456789abcdef0123456789abcdef", SHA1: "0123456789abcdef0123456789abcdef01234567",
+			},
+			{
+				AuthID: "0 ; 274831 (00000000:0004318f)", Session: "Interactive from 1", LogonUser: "jdoe", LogonDomain: "CORP",
+				LogonServer: "DC01", SID: "S-1-5-21-1004336348-1177238915-682003330-1104", Package: "msv", Username: "jdoe", Domain: "CORP",
+				NTLM: "8846f7eaee8fb117ad06bdd830b7586c", SHA1: "e8f97fba9104d1ea5047948e6dfb67facd9f5b73",
+			},
+			{
+				AuthID: "0 ; 274831 (00000000:0004318f)", Session: "Interactive from 1", LogonUser: "jdoe", LogonDomain: "CORP",
+				LogonServer: "DC01", SID: "S-1-5-21-1004336348-1177238915-682003330-1104", Package: "wdigest", Username: "jdoe", Domain: "CORP",
+				Password: "Summer2024!",
+			},
+		}},
+		{"portscan", func() interface{} { return ParsePortScan(portScanOutput) }, []PortScanResult{
+			{Host: "10.0.0.10", Port: 445, Service: "smb", Banner: "platform: 500 version: 10.0 name: DC01 domain: CORP", NetBIOSName: "DC01", Domain: "CORP", OSVersion: "10.0"},
+			{Host: "10.0.0.10", Port: 88, Service: "kerberos"},
+			{Host: "10.0.0.20", Port: 22, Service: "ssh", Banner: "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.6"},
+			{Host: "10.0.0.15", Port: 3389, Service: "rdp"},
+		}},
+		{"drives", func() interface{} { return ParseDrives(drivesOutput) }, []string{"C:", "D:"}},
+		{"net computers", func() interface{} { return ParseNetHosts(crlf(netHostsOutput)) }, []NetHost{
+			{Name: "DC01", Address: "10.0.0.10", Platform: "500", Version: "10.0", Type: "PDC", Comment: "Primary DC"},
+			{Name: "WS01", Address: "10.0.0.15", Platform: "500", Version: "10.0"},
+		}},
+		{"net domain_trusts", func() interface{} { return ParseNetTrusts(netTrustsOutput) }, []NetTrust{
+			{NetBIOSName: "CORP", DNSName: "corp.local", Flags: []string{"Forest tree root", "Primary Domain", "Native", "Direct Outbound", "Direct Inbound", "Attr: 0x8"}},
+			{NetBIOSName: "DEV", DNSName: "dev.corp.local", Flags: []string{"Forest: 0", "Native", "Direct Outbound", "Direct Inbound", "Attr: 0x20"}},
+		}},
+		{"net group", func() interface{} { return ParseNetGroups(netGroupsOutput) }, []NetGroupEntry{
+			{Name: "Domain Admins", Comment: "Designated administrators of the domain"},
+			{Name: "Domain Users", Comment: "All domain users"},
+			{Name: "Protected Users"},
+		}},
+		{"net group members", func() interface{} { return ParseNetGroups(netGroupMembersOutput) }, []NetGroupEntry{
+			{Name: "Administrator"},
+			{Name: "jdoe"},
+		}},
+		{"net sessions", func() interface{} { return ParseNetSessions(netSessionsOutput) }, []NetSession{
+			{Client: `\\10.0.0.15`, User: "jdoe", Active: 120, Idle: 5},
+			{Client: `\\[::1]`, User: "Administrator", Active: 3600},
+		}},
+		{"net share", func() interface{} { return ParseNetShares(netSharesOutput) }, []NetShare{
+			{Name: "ADMIN$", Comment: "Remote Admin"},
+			{Name: "C$", Comment: "Default share"},
+			{Name: "IPC$", Comment: "Remote IPC"},
+			{Name: "NETLOGON", Comment: "Logon server share"},
+		}},
+		{"net user", func() interface{} { return ParseNetUsers(netUsersOutput) }, []NetUserEntry{
+			{Name: "Administrator", Admin: true},
+			{Name: "Guest"},
+			{Name: "jdoe"},
+			{Name: "krbtgt"},
+		}},
+		{"net logons", func() interface{} { return ParseNetLogons(netLogonsOutput) }, []string{`CORP\jdoe`, `CORP\WS01$`}},
+		{"sc queryex", func() interface{} { return ParseServiceStatus(crlf(scQueryexOutput)) }, []ServiceStatus{
+			{Name: "Spooler", DisplayName: "Print Spooler", Type: "WIN32_OWN_PROCESS", State: "RUNNING", PID: 2184},
+			{Name: "wuauserv", DisplayName: "Windows Update", Type: "WIN32_SHARE_PROCESS", State: "STOPPED", ExitCode: 1077},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhoamiAllGroupNameIsDeterministic(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		groups := ParseWhoamiAll(whoamiExtraColumnOutput).Groups
+		if len(groups) != 2 || groups[0].Name != "Backup Operators" {
+			t.Fatalf("got %+v", groups)
+		}
+	}
+}
+
+// The fixtures below follow the layouts printed by the situational awareness
+// BOFs of CS-Situational-Awareness-BOF, including the beacon's status lines
+
+const saWhoamiOutput = `received output:
+
+UserName		SID
+====================== ====================================
+CORP\jdoe	S-1-5-21-1004336348-1177238915-682003330-1104
+
+
+
+GROUP INFORMATION                                 Type                     SID                                          Attributes               
+================================================= ===================== ============================================= ==================================================
+CORP\Domain Users                                 Group                    S-1-5-21-1004336348-1177238915-682003330-513 Mandatory group, Enabled by default, Enabled group, 
+Everyone                                          Well-known group         S-1-1-0                                      Mandatory group, Enabled by default, Enabled group, 
+BUILTIN\Administrators                            Alias                    S-1-5-32-544                                 Group used for deny only, 
+Mandatory Label\Medium Mandatory Level            Label                    S-1-16-8192                                  
+
+
+Privilege Name                Description                                       State                         
+============================= ================================================= ===========================
+SeShutdownPrivilege           Shut down the system                              Disabled                      
+SeChangeNotifyPrivilege       Bypass traverse checking                          Enabled                       
+SeIncreaseWorkingSetPrivilege Increase a process working set                    Disabled                      
+`
+
+const saIPConfigOutput = "received output:\n" +
+	"{4D36E972-E325-11CE-BFC1-08002BE10318}\n" +
+	"\tIntel(R) 82574L Gigabit Network Connection\n" +
+	"\t\t00-0C-29-AB-CD-EF\n" +
+	"\t\t10.0.0.15\n" +
+	"\t\tfe80::1c2d:3e4f:5a6b:7c8d%12\n" +
+	"{8A6B2C1D-3E4F-4A5B-9C8D-7E6F5A4B3C2D}\n" +
+	"\tMicrosoft Wi-Fi Direct Virtual Adapter\n" +
+	"\t\t02-0C-29-AB-CD-EF\n" +
+	"\t\t0.0.0.0\n" +
+	"Hostname: \tWS01\n" +
+	"DNS Suffix: \tcorp.local\n" +
+	"DNS Server: \t10.0.0.10\n" +
+	"\t\t10.0.0.11\n"
+
+const saNetstatOutput = `received output:
+
+Proto   Local Address           Foreign Address         State           PID     Process
+TCP     0.0.0.0:135             0.0.0.0:0               LISTENING       912     svchost.exe
+TCP     10.0.0.15:49712         10.0.0.10:445           ESTABLISHED     4       System
+TCP     [::]:445                [::]:0                  LISTENING       4       System
+UDP     0.0.0.0:123             *:*                                     1288    svchost.exe
+UDP     [fe80::1c2d:3e4f:5a6b:7c8d%12]:1900*:*                                     3344    svchost.exe
+`
+
+const saListDNSOutput = `received output:
+Name                                                        Type    Data
+wpad.corp.local                                             A       10.0.0.12
+dc01.corp.local                                             A       10.0.0.10
+login.microsoftonline.com                                   CNAME   login.mso.msidentity.com
+dc01.corp.local                                             AAAA    fe80::1
+_ldap._tcp.dc._msdcs.corp.local                             SRV     dc01.corp.local
+settings-win.data.microsoft.com
+[-] DnsQuery for settings-win.data.microsoft.com failed: 9701
+`
+
+const saEnvOutput = "received output:\n" +
+	"Gathering environment variables:\n" +
+	"=::=::\\\n" +
+	"=C:=C:\\Windows\\system32\n" +
+	"ALLUSERSPROFILE=C:\\ProgramData\n" +
+	"COMPUTERNAME=WS01\n" +
+	"Path=C:\\Windows\\system32;C:\\Windows\n" +
+	"PROMPT=$P$G\n" +
+	"USERDNSDOMAIN=CORP.LOCAL\n" +
+	"EMPTY=\n"
+
+func TestParseSABOFOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() interface{}
+		want interface{}
+	}{
+		{"whoami", func() interface{} { return ParseWhoamiAll(saWhoamiOutput) }, WhoamiInfo{
+			User: `CORP\jdoe`,
+			SID:  "S-1-5-21-1004336348-1177238915-682003330-1104",
+			Groups: []GroupEntry{
+				{Name: `CORP\Domain Users`, Type: "Group", SID: "S-1-5-21-1004336348-1177238915-682003330-513", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+				{Name: "Everyone", Type: "Well-known group", SID: "S-1-1-0", Attributes: []string{"Mandatory group", "Enabled by default", "Enabled group"}},
+				{Name: `BUILTIN\Administrators`, Type: "Alias", SID: "S-1-5-32-544", Attributes: []string{"Group used for deny only"}},
+				{Name: `Mandatory Label\Medium Mandatory Level`, Type: "Label", SID: "S-1-16-8192"},
+			},
+			Privileges: []PrivilegeEntry{
+				{Name: "SeShutdownPrivilege", Description: "Shut down the system", State: "Disabled"},
+				{Name: "SeChangeNotifyPrivilege", Description: "Bypass traverse checking", State: "Enabled"},
+				{Name: "SeIncreaseWorkingSetPrivilege", Description: "Increase a process working set", State: "Disabled"},
+			},
+		}},
+		{"ipconfig", func() interface{} {
+			adapters := ParseIPConfig(saIPConfigOutput)
+			for i := range adapters {
+				adapters[i].Properties = nil
+			}
+			return adapters
+		}, []NetworkAdapter{
+			{
+				Name:       "{4D36E972-E325-11CE-BFC1-08002BE10318}",
+				MAC:        "00-0C-29-AB-CD-EF",
+				IPv4:       []string{"10.0.0.15"},
+				IPv6:       []string{"fe80::1c2d:3e4f:5a6b:7c8d%12"},
+				DNSServers: []string{"10.0.0.10", "10.0.0.11"},
+			},
+			{
+				Name:       "{8A6B2C1D-3E4F-4A5B-9C8D-7E6F5A4B3C2D}",
+				MAC:        "02-0C-29-AB-CD-EF",
+				IPv4:       []string{"0.0.0.0"},
+				DNSServers: []string{"10.0.0.10", "10.0.0.11"},
+			},
+		}},
+		{"ipconfig description", func() interface{} { return ParseIPConfig(saIPConfigOutput)[0].Properties["Description"] }, []string{"Intel(R) 82574L Gigabit Network Connection"}},
+		{"netstat", func() interface{} { return ParseNetstat(saNetstatOutput) }, []NetstatEntry{
+			{Proto: "TCP", Local: "0.0.0.0:135", Foreign: "0.0.0.0:0", State: "LISTENING", PID: 912},
+			{Proto: "TCP", Local: "10.0.0.15:49712", Foreign: "10.0.0.10:445", State: "ESTABLISHED", PID: 4},
+			{Proto: "TCP", Local: "[::]:445", Foreign: "[::]:0", State: "LISTENING", PID: 4},
+			{Proto: "UDP", Local: "0.0.0.0:123", Foreign: "*:*", PID: 1288},
+			{Proto: "UDP", Local: "[fe80::1c2d:3e4f:5a6b:7c8d%12]:1900", Foreign: "*:*", PID: 3344},
+		}},
+		{"listdns", func() interface{} { return ParseDNSCache(saListDNSOutput) }, []DNSCacheEntry{
+			{Name: "wpad.corp.local", Type: "A", Data: "10.0.0.12"},
+			{Name: "dc01.corp.local", Type: "A", Data: "10.0.0.10"},
+			{Name: "login.microsoftonline.com", Type: "CNAME", Data: "login.mso.msidentity.com"},
+			{Name: "dc01.corp.local", Type: "AAAA", Data: "fe80::1"},
+			{Name: "_ldap._tcp.dc._msdcs.corp.local", Type: "SRV", Data: "dc01.corp.local"},
+			{Name: "settings-win.data.microsoft.com"},
+		}},
+		{"listdns header only", func() interface{} { return ParseDNSCache("Name    Type\n----    ----\n") }, []DNSCacheEntry(nil)},
+		{"env", func() interface{} { return ParseEnvironment(saEnvOutput) }, map[string]string{
+			"=::":             `::\`,
+			"=C:":             `C:\Windows\system32`,
+			"ALLUSERSPROFILE": `C:\ProgramData`,
+			"COMPUTERNAME":    "WS01",
+			"Path":            `C:\Windows\system32;C:\Windows`,
+			"PROMPT":          "$P$G",
+			"USERDNSDOMAIN":   "CORP.LOCAL",
+			"EMPTY":           "",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package csclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// PipelineStep is one command of a pipeline. Its parsed output is stored in
+// the pipeline variables under Name, where later steps' argument templates
+// can use it (e.g. "{{.whoami.User}}").
+type PipelineStep struct {
+	Name string // Variable the parsed output is stored under; also used in errors
+	// Submit renders the step's templates with vars and submits it to the beacon
+	Submit func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error)
+	// Parse turns the finished task into the step output; an error aborts the
+	// pipeline. Defaults to the task's text output.
+	Parse func(task *TaskDetailDto) (interface{}, error)
+}
+
+// WithParser returns a copy of the step using parse for its output
+func (s PipelineStep) WithParser(parse func(task *TaskDetailDto) (interface{}, error)) PipelineStep {
+	s.Parse = parse
+	return s
+}
+
+// TextParser adapts a console output parser such as ParseWhoamiAll for WithParser
+func TextParser[T any](parse func(output string) T) func(task *TaskDetailDto) (interface{}, error) {
+	return func(task *TaskDetailDto) (interface{}, error) {
+		return parse(TaskOutputText(task)), nil
+	}
+}
+
+// ShellStep runs a shell command rendered from a template
+func ShellStep(name, command string) PipelineStep {
+	return PipelineStep{
+		Name: name,
+		Submit: func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error) {
+			rendered, err := renderPipelineTemplate(command, vars)
+			if err != nil {
+				return nil, err
+			}
+			return c.ExecuteShell(ctx, bid, rendered)
+		},
+	}
+}
+
+// RunStep starts a program, without cmd.exe, from a command line template
+func RunStep(name, command string) PipelineStep {
+	return PipelineStep{
+		Name: name,
+		Submit: func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error) {
+			rendered, err := renderPipelineTemplate(command, vars)
+			if err != nil {
+				return nil, err
+			}
+			return c.Run(ctx, bid, rendered)
+		},
+	}
+}
+
+// ConsoleStep runs a console command whose Command and Arguments are templates
+func ConsoleStep(name string, cmd CommandDto) PipelineStep {
+	return PipelineStep{
+		Name: name,
+		Submit: func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error) {
+			rendered := cmd
+			var err error
+			if rendered.Command, err = renderPipelineTemplate(cmd.Command, vars); err != nil {
+				return nil, err
+			}
+			if rendered.Arguments, err = renderPipelineTemplate(cmd.Arguments, vars); err != nil {
+				return nil, err
+			}
+			return c.ExecuteConsoleCommand(ctx, bid, rendered)
+		},
+	}
+}
+
+// BOFStep executes a BOF whose string and wide string arguments are templates.
+// Error callbacks reported by the BOF abort the pipeline.
+func BOFStep(name string, req InlineExecutePackDto) PipelineStep {
+	return PipelineStep{
+		Name: name,
+		Submit: func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error) {
+			args, err := renderBOFArguments(req.Arguments, vars)
+			if err != nil {
+				return nil, err
+			}
+			rendered := req
+			rendered.Arguments = args
+			return c.ExecuteBOFPack(ctx, bid, rendered)
+		},
+		Parse: parseBOFStepOutput,
+	}
+}
+
+// BOFFileStep executes a local BOF object file (see ExecuteBOFFile) whose
+// string and wide string arguments are templates. Error callbacks reported by
+// the BOF abort the pipeline.
+func BOFFileStep(name, path, entrypoint string, args ...BOFArgument) PipelineStep {
+	return PipelineStep{
+		Name: name,
+		Submit: func(ctx context.Context, c *Client, bid string, vars map[string]interface{}) (*AsyncCommandResponse, error) {
+			rendered, err := renderBOFArguments(args, vars)
+			if err != nil {
+				return nil, err
+			}
+			return c.ExecuteBOFFile(ctx, bid, path, entrypoint, rendered...)
+		},
+		Parse: parseBOFStepOutput,
+	}
+}
+
+// parseBOFStepOutput returns the text output of a BOF, failing on error callbacks
+func parseBOFStepOutput(task *TaskDetailDto) (interface{}, error) {
+	outputs, err := DecodeBOFOutput(task)
+	if err != nil {
+		return nil, err
+	}
+	var text, errs []string
+	for _, out := range outputs {
+		if out.CallbackType == BOFCallbackError {
+			errs = append(errs, strings.TrimSpace(out.Text()))
+		} else if out.CallbackType.IsOutput() {
+			text = append(text, out.Text())
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("BOF reported an error: %s", strings.Join(errs, "; "))
+	}
+	return strings.Join(text, ""), nil
+}
+
+// renderBOFArguments renders the templates in string and wide string arguments
+func renderBOFArguments(args []BOFArgument, vars map[string]interface{}) ([]BOFArgument, error) {
+	rendered := make([]BOFArgument, len(args))
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case StringArg:
+			value, err := renderPipelineTemplate(a.Value, vars)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			a.Value = value
+			rendered[i] = a
+		case WStringArg:
+			value, err := renderPipelineTemplate(a.Value, vars)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			a.Value = value
+			rendered[i] = a
+		default:
+			rendered[i] = arg
+		}
+	}
+	return rendered, nil
+}
+
+// renderPipelineTemplate renders a text/template with the pipeline variables.
+// Referencing a variable that is not set is an error.
+func renderPipelineTemplate(text string, vars map[string]interface{}) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("step").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %q: %w", text, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", text, err)
+	}
+	return sb.String(), nil
+}
+
+// PipelineOption configures RunPipeline
+type PipelineOption func(*pipelineConfig)
+
+// pipelineConfig holds the settings used by RunPipeline
+type pipelineConfig struct {
+	timeout time.Duration
+	vars    map[string]interface{}
+}
+
+// WithPipelineTimeout sets how long to wait for each step's task; by default
+// the TaskWait entry of the timeout profile is used
+func WithPipelineTimeout(timeout time.Duration) PipelineOption {
+	return func(cfg *pipelineConfig) { cfg.timeout = timeout }
+}
+
+// WithPipelineVars seeds the pipeline variables available to every template.
+// The name "bid" is reserved for the beacon ID.
+func WithPipelineVars(vars map[string]interface{}) PipelineOption {
+	return func(cfg *pipelineConfig) { cfg.vars = vars }
+}
+
+// PipelineStepResult is the outcome of one pipeline step
+type PipelineStepResult struct {
+	Name     string
+	Response *AsyncCommandResponse // Nil if submission failed
+	Task     *TaskDetailDto        // Set once the task finished
+	Output   interface{}           // Parsed output, also stored in the variables
+	Err      error
+}
+
+// PipelineResult holds the results of the steps that ran and the final variables
+type PipelineResult struct {
+	Steps []PipelineStepResult
+	Vars  map[string]interface{}
+}
+
+// PipelineError reports the step that aborted a pipeline
+type PipelineError struct {
+	Step  string
+	Index int
+	Err   error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("pipeline step %d (%s) failed: %v", e.Index+1, e.Step, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// pipelineBIDVar is the pipeline variable holding the beacon ID; steps and
+// seeded variables cannot use the name
+const pipelineBIDVar = "bid"
+
+// RunPipeline runs steps against a beacon in order, waiting for each task and
+// storing its parsed output under the step name before rendering the next
+// step. The variable "bid" holds the beacon ID and is reserved. The first step
+// that fails to render, submit, complete or parse aborts the pipeline with a
+// *PipelineError, returned alongside the results of the steps that ran.
+func (c *Client) RunPipeline(ctx context.Context, bid string, steps []PipelineStep, opts ...PipelineOption) (*PipelineResult, error) {
+	var cfg pipelineConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if _, ok := cfg.vars[pipelineBIDVar]; ok {
+		return nil, fmt.Errorf("failed to run pipeline: %w", &ValidationError{Field: "var", Value: pipelineBIDVar, Reason: "is reserved for the beacon ID"})
+	}
+	names := make(map[string]bool, len(steps))
+	for i, step := range steps {
+		if step.Submit == nil {
+			return nil, fmt.Errorf("failed to run pipeline: %w", &ValidationError{Field: "step", Value: step.Name, Reason: fmt.Sprintf("step %d has no Submit function", i+1)})
+		}
+		if step.Name == pipelineBIDVar {
+			return nil, fmt.Errorf("failed to run pipeline: %w", &ValidationError{Field: "step", Value: step.Name, Reason: "is reserved for the beacon ID"})
+		}
+		if step.Name != "" && names[step.Name] {
+			return nil, fmt.Errorf("failed to run pipeline: %w", &ValidationError{Field: "step", Value: step.Name, Reason: "step names must be unique"})
+		}
+		names[step.Name] = true
+	}
+
+	result := &PipelineResult{Vars: map[string]interface{}{pipelineBIDVar: bid}}
+	for k, v := range cfg.vars {
+		result.Vars[k] = v
+	}
+	for i, step := range steps {
+		res := PipelineStepResult{Name: step.Name}
+		res.Err = c.runPipelineStep(ctx, bid, step, cfg.timeout, result.Vars, &res)
+		result.Steps = append(result.Steps, res)
+		if res.Err != nil {
+			return result, &PipelineError{Step: step.Name, Index: i, Err: res.Err}
+		}
+		if step.Name != "" {
+			result.Vars[step.Name] = res.Output
+		}
+	}
+	return result, nil
+}
+
+// runPipelineStep submits a step, waits for its task and parses its output
+// into res, returning the error that aborts the pipeline
+func (c *Client) runPipelineStep(ctx context.Context, bid string, step PipelineStep, timeout time.Duration, vars map[string]interface{}, res *PipelineStepResult) error {
+	resp, err := step.Submit(ctx, c, bid, vars)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("no response returned")
+	}
+	res.Response = resp
+	if res.Task, err = c.waitForResponse(ctx, resp, timeout); err != nil {
+		return err
+	}
+	if res.Task.TaskStatus == TaskStatusFailed {
+		return fmt.Errorf("task %s failed", res.Task.TaskID)
+	}
+	if step.Parse == nil {
+		res.Output = TaskOutputText(res.Task)
+		return nil
+	}
+	res.Output, err = step.Parse(res.Task)
+	return err
+}
@@ -0,0 +1,191 @@
+package csclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PivotNode is a beacon in the pivot graph
+type PivotNode struct {
+	Beacon   BeaconDto    `json:"beacon"`
+	Parent   *PivotNode   `json:"-"`
+	Children []*PivotNode `json:"children,omitempty"`
+}
+
+// PivotGraph is the link topology of beacons, built from BID/PBID.
+// Beacons whose parent is absent from the listing are treated as roots.
+type PivotGraph struct {
+	Roots []*PivotNode
+	nodes map[string]*PivotNode
+}
+
+// BeaconGraph lists beacons and builds their pivot graph
+func (c *Client) BeaconGraph(ctx context.Context) (*PivotGraph, error) {
+	beacons, err := c.ListBeacons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build beacon graph: %w", err)
+	}
+	return BuildPivotGraph(beacons), nil
+}
+
+// BuildPivotGraph builds a pivot graph from a beacon listing
+func BuildPivotGraph(beacons []BeaconDto) *PivotGraph {
+	g := &PivotGraph{nodes: make(map[string]*PivotNode, len(beacons))}
+	for _, b := range beacons {
+		g.nodes[b.BID] = &PivotNode{Beacon: b}
+	}
+
+	bids := make([]string, 0, len(g.nodes))
+	for bid := range g.nodes {
+		bids = append(bids, bid)
+	}
+	sort.Strings(bids)
+
+	for _, bid := range bids {
+		node := g.nodes[bid]
+		parent, ok := g.nodes[node.Beacon.PBID]
+		if !ok || node.Beacon.PBID == "" || g.isAncestor(node, parent) {
+			g.Roots = append(g.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	return g
+}
+
+// isAncestor reports whether node is parent or one of its attached ancestors,
+// guarding against PBID cycles
+func (g *PivotGraph) isAncestor(node, parent *PivotNode) bool {
+	for p := parent; p != nil; p = p.Parent {
+		if p == node {
+			return true
+		}
+	}
+	return false
+}
+
+// Node returns the node for a beacon ID, or nil if it is not in the graph
+func (g *PivotGraph) Node(bid string) *PivotNode {
+	return g.nodes[bid]
+}
+
+// Len returns the number of beacons in the graph
+func (g *PivotGraph) Len() int {
+	return len(g.nodes)
+}
+
+// Walk visits every node depth-first, parents before children. Returning
+// false from fn skips the node's children.
+func (g *PivotGraph) Walk(fn func(n *PivotNode, depth int) bool) {
+	var visit func(n *PivotNode, depth int)
+	visit = func(n *PivotNode, depth int) {
+		if !fn(n, depth) {
+			return
+		}
+		for _, child := range n.Children {
+			visit(child, depth+1)
+		}
+	}
+	for _, root := range g.Roots {
+		visit(root, 0)
+	}
+}
+
+// Path returns the chain of nodes from the root down to the given beacon
+func (g *PivotGraph) Path(bid string) []*PivotNode {
+	var path []*PivotNode
+	for n := g.nodes[bid]; n != nil; n = n.Parent {
+		path = append([]*PivotNode{n}, path...)
+	}
+	return path
+}
+
+// Descendants returns every node linked beneath the given beacon, nearest first.
+// These are the sessions lost if the beacon is unlinked or exits.
+func (g *PivotGraph) Descendants(bid string) []*PivotNode {
+	n := g.nodes[bid]
+	if n == nil {
+		return nil
+	}
+	var result []*PivotNode
+	queue := append([]*PivotNode(nil), n.Children...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		result = append(result, next)
+		queue = append(queue, next.Children...)
+	}
+	return result
+}
+
+// Depth returns the number of hops between the beacon and its root, or -1 if unknown
+func (n *PivotNode) Depth() int {
+	if n == nil {
+		return -1
+	}
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Edges are labelled with
+// the link state and dashed when the link is broken.
+func (g *PivotGraph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph beacons {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	g.Walk(func(n *PivotNode, _ int) bool {
+		b := n.Beacon
+		label := fmt.Sprintf("%s\\n%s @ %s\\n%s (%d)", b.BID, dotEscape(b.User), dotEscape(b.Computer), dotEscape(b.Process), b.PID)
+		attrs := fmt.Sprintf("label=\"%s\"", label)
+		if !b.Alive {
+			attrs += ", style=dashed, fontcolor=gray"
+		} else if b.IsAdmin {
+			attrs += ", color=red"
+		}
+		fmt.Fprintf(&sb, "  \"%s\" [%s];\n", dotEscape(b.BID), attrs)
+		return true
+	})
+
+	g.Walk(func(n *PivotNode, _ int) bool {
+		if n.Parent == nil {
+			return true
+		}
+		attrs := fmt.Sprintf("label=\"%s\"", dotEscape(n.Beacon.LinkState))
+		if !strings.EqualFold(n.Beacon.LinkState, "OK") && n.Beacon.LinkState != "" {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "  \"%s\" -> \"%s\" [%s];\n", dotEscape(n.Parent.Beacon.BID), dotEscape(n.Beacon.BID), attrs)
+		return true
+	})
+
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON writes the graph as a JSON array of root nodes with nested children
+func (g *PivotGraph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	roots := g.Roots
+	if roots == nil {
+		roots = []*PivotNode{}
+	}
+	return enc.Encode(roots)
+}
+
+// dotEscape escapes a value for use inside a quoted DOT string
+func dotEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
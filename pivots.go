@@ -0,0 +1,100 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// LinkDto links to a waiting SMB beacon
+type LinkDto struct {
+	Target string `json:"target"`
+	Pipe   string `json:"pipe,omitempty"`
+}
+
+// UnlinkDto disconnects a linked beacon
+type UnlinkDto struct {
+	Host string `json:"host"`
+	PID  int    `json:"pid,omitempty"`
+}
+
+// Link connects to an SMB beacon waiting on target. An empty pipename uses the
+// pipe of the profile's SMB listener.
+func (c *Client) Link(ctx context.Context, bid, target, pipename string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/link/smb")
+	if err != nil {
+		return nil, fmt.Errorf("failed to link: %w", err)
+	}
+	if target == "" {
+		return nil, fmt.Errorf("failed to link: %w", &ValidationError{Field: "target", Value: target, Reason: "must not be empty"})
+	}
+	if err := c.doRequest(ctx, "POST", path, LinkDto{Target: target, Pipe: pipename}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to link: %w", err)
+	}
+	return &resp, nil
+}
+
+// Unlink disconnects the child beacon targetBID from its parent bid. The
+// child's internal address and PID identify the link.
+func (c *Client) Unlink(ctx context.Context, bid, targetBID string) (*AsyncCommandResponse, error) {
+	path, err := beaconPath(bid, "/execute/unlink")
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlink: %w", err)
+	}
+	child, err := c.GetBeacon(ctx, targetBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlink: %w", err)
+	}
+	if child.PBID != bid {
+		return nil, fmt.Errorf("failed to unlink: %w", &ValidationError{Field: "target beacon ID", Value: targetBID, Reason: "is not linked to beacon " + bid})
+	}
+
+	var resp AsyncCommandResponse
+	if err := c.doRequest(ctx, "POST", path, UnlinkDto{Host: child.Internal, PID: child.PID}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to unlink: %w", err)
+	}
+	return &resp, nil
+}
+
+// UnlinkHost disconnects the beacon linked from host, optionally selected by its PID
+func (c *Client) UnlinkHost(ctx context.Context, bid, host string, pid int) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/unlink")
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlink: %w", err)
+	}
+	if pid < 0 {
+		return nil, fmt.Errorf("failed to unlink: %w", &ValidationError{Field: "pid", Value: strconv.Itoa(pid), Reason: "must not be negative"})
+	}
+	if err := c.doRequest(ctx, "POST", path, UnlinkDto{Host: host, PID: pid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to unlink: %w", err)
+	}
+	return &resp, nil
+}
+
+// ConnectDto connects to a waiting TCP beacon
+type ConnectDto struct {
+	Target string `json:"target"`
+	Port   int    `json:"port,omitempty"`
+}
+
+// Connect connects to a TCP beacon waiting on target. A zero port uses the
+// port of the profile's TCP listener.
+func (c *Client) Connect(ctx context.Context, bid, target string, port int) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/link/tcp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	if target == "" {
+		return nil, fmt.Errorf("failed to connect: %w", &ValidationError{Field: "target", Value: target, Reason: "must not be empty"})
+	}
+	if port < 0 || port > 65535 {
+		return nil, fmt.Errorf("failed to connect: %w", &ValidationError{Field: "port", Value: strconv.Itoa(port), Reason: "must be between 1 and 65535"})
+	}
+	if err := c.doRequest(ctx, "POST", path, ConnectDto{Target: target, Port: port}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return &resp, nil
+}
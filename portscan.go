@@ -0,0 +1,77 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Port scan host discovery methods
+const (
+	DiscoveryARP  = "arp"
+	DiscoveryICMP = "icmp"
+	DiscoveryNone = "none"
+)
+
+// PortScanSpawnDto runs the port scanner in a spawned process
+type PortScanSpawnDto struct {
+	Targets        []string `json:"targets"`
+	Ports          []string `json:"ports"`
+	Method         string   `json:"method"`
+	MaxConnections int      `json:"maxConnections"`
+}
+
+// splitList splits a comma or whitespace separated list
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' })
+}
+
+// PortScan scans targets (hosts, ranges or CIDRs, comma separated) on ports
+// (e.g. "22,80,443,8000-8080"). discovery is DiscoveryARP, DiscoveryICMP or
+// DiscoveryNone; an empty value uses ARP. Parse the task output with ParsePortScan.
+func (c *Client) PortScan(ctx context.Context, bid string, targets string, ports string, discovery string, maxConnections int) (*AsyncCommandResponse, error) {
+	req := PortScanSpawnDto{
+		Targets:        splitList(targets),
+		Ports:          splitList(ports),
+		Method:         discovery,
+		MaxConnections: maxConnections,
+	}
+	if req.Method == "" {
+		req.Method = DiscoveryARP
+	}
+	if req.MaxConnections <= 0 {
+		req.MaxConnections = 1024
+	}
+	switch {
+	case len(req.Targets) == 0:
+		return nil, fmt.Errorf("failed to execute portscan: %w", &ValidationError{Field: "targets", Value: targets, Reason: "must not be empty"})
+	case len(req.Ports) == 0:
+		return nil, fmt.Errorf("failed to execute portscan: %w", &ValidationError{Field: "ports", Value: ports, Reason: "must not be empty"})
+	case req.Method != DiscoveryARP && req.Method != DiscoveryICMP && req.Method != DiscoveryNone:
+		return nil, fmt.Errorf("failed to execute portscan: %w", &ValidationError{Field: "discovery", Value: discovery, Reason: "must be arp, icmp or none"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/portscan")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute portscan: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute portscan: %w", err)
+	}
+	return &resp, nil
+}
+
+// wellKnownPorts names the services of common ports in scan results
+var wellKnownPorts = map[int]string{
+	21: "ftp", 22: "ssh", 23: "telnet", 25: "smtp", 53: "dns", 80: "http", 88: "kerberos",
+	110: "pop3", 135: "msrpc", 139: "netbios-ssn", 143: "imap", 389: "ldap", 443: "https",
+	445: "smb", 636: "ldaps", 1433: "mssql", 1521: "oracle", 2049: "nfs", 3268: "globalcatalog",
+	3306: "mysql", 3389: "rdp", 5432: "postgresql", 5900: "vnc", 5985: "winrm", 5986: "winrm-https",
+	8080: "http-alt", 8443: "https-alt",
+}
+
+// PortServiceName returns the conventional service name of a port, or "" if unknown
+func PortServiceName(port int) string {
+	return wellKnownPorts[port]
+}
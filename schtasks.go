@@ -0,0 +1,256 @@
+package csclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheduled task triggers accepted by ScheduledTask.Schedule
+const (
+	ScheduleOnce    = "ONCE"
+	ScheduleOnStart = "ONSTART"
+	ScheduleOnLogon = "ONLOGON"
+	ScheduleDaily   = "DAILY"
+	ScheduleHourly  = "HOURLY"
+)
+
+// ScheduledTask describes a scheduled task to create
+type ScheduledTask struct {
+	Name     string // Task name, e.g. \Microsoft\Windows\Maintenance\Updater
+	Target   string // Remote host; empty for the beacon's host
+	Command  string // Program and arguments the task runs
+	Schedule string // Trigger; defaults to ScheduleOnce
+	// StartTime is the HH:mm start of ONCE, DAILY and HOURLY tasks. It
+	// defaults to 00:00, which schtasks accepts with a warning; trigger the
+	// task with ScheduledTaskRun for immediate execution.
+	StartTime string
+	RunAs     string // Account the task runs as, e.g. SYSTEM; empty for the beacon's user
+}
+
+// schtasks runs schtasks.exe without cmd.exe, waits for it and returns its
+// output or the "ERROR:" line it reported
+func (c *Client) schtasks(ctx context.Context, bid, target string, args ...string) (string, error) {
+	command := "schtasks.exe " + args[0]
+	if target != "" {
+		if err := validateRemoteHost(target); err != nil {
+			return "", err
+		}
+		command += " /s " + quoteSchtasksArg(strings.TrimPrefix(target, `\\`))
+	}
+	for _, arg := range args[1:] {
+		command += " " + arg
+	}
+	output, err := c.runOutput(ctx, bid, command)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range outputLines(output) {
+		if msg, ok := strings.CutPrefix(strings.TrimSpace(line), "ERROR:"); ok {
+			return "", errors.New(strings.TrimSpace(msg))
+		}
+	}
+	return output, nil
+}
+
+// quoteSchtasksArg quotes a schtasks argument, escaping embedded quotes
+func quoteSchtasksArg(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// ScheduledTaskCreate creates (or replaces) a scheduled task
+func (c *Client) ScheduledTaskCreate(ctx context.Context, bid string, task ScheduledTask) error {
+	if task.Name == "" || strings.ContainsAny(task.Name, "\"\r\n") {
+		return fmt.Errorf("failed to create scheduled task: %w", &ValidationError{Field: "name", Value: task.Name, Reason: "must be non-empty without quotes or newlines"})
+	}
+	if task.Command == "" || strings.ContainsAny(task.Command, "\r\n") {
+		return fmt.Errorf("failed to create scheduled task: %w", &ValidationError{Field: "command", Value: task.Command, Reason: "must be non-empty without newlines"})
+	}
+	schedule := strings.ToUpper(task.Schedule)
+	if schedule == "" {
+		schedule = ScheduleOnce
+	}
+	switch schedule {
+	case ScheduleOnce, ScheduleOnStart, ScheduleOnLogon, ScheduleDaily, ScheduleHourly:
+	default:
+		return fmt.Errorf("failed to create scheduled task: %w", &ValidationError{Field: "schedule", Value: task.Schedule, Reason: "must be ONCE, ONSTART, ONLOGON, DAILY or HOURLY"})
+	}
+
+	args := []string{"/create", "/tn", quoteSchtasksArg(task.Name), "/tr", quoteSchtasksArg(task.Command), "/sc", schedule}
+	if schedule == ScheduleOnce || schedule == ScheduleDaily || schedule == ScheduleHourly {
+		start := task.StartTime
+		if start == "" {
+			start = "00:00"
+		}
+		if _, err := time.Parse("15:04", start); err != nil {
+			return fmt.Errorf("failed to create scheduled task: %w", &ValidationError{Field: "startTime", Value: start, Reason: "must be HH:mm"})
+		}
+		args = append(args, "/st", start)
+	}
+	if task.RunAs != "" {
+		args = append(args, "/ru", quoteSchtasksArg(task.RunAs))
+	}
+	args = append(args, "/f")
+
+	if _, err := c.schtasks(ctx, bid, task.Target, args...); err != nil {
+		return fmt.Errorf("failed to create scheduled task: %w", err)
+	}
+	return nil
+}
+
+// ScheduledTaskRun triggers a scheduled task immediately
+func (c *Client) ScheduledTaskRun(ctx context.Context, bid, target, name string) error {
+	if name == "" {
+		return fmt.Errorf("failed to run scheduled task: %w", &ValidationError{Field: "name", Value: name, Reason: "must not be empty"})
+	}
+	if _, err := c.schtasks(ctx, bid, target, "/run", "/tn", quoteSchtasksArg(name)); err != nil {
+		return fmt.Errorf("failed to run scheduled task: %w", err)
+	}
+	return nil
+}
+
+// ScheduledTaskDelete deletes a scheduled task
+func (c *Client) ScheduledTaskDelete(ctx context.Context, bid, target, name string) error {
+	if name == "" {
+		return fmt.Errorf("failed to delete scheduled task: %w", &ValidationError{Field: "name", Value: name, Reason: "must not be empty"})
+	}
+	if _, err := c.schtasks(ctx, bid, target, "/delete", "/tn", quoteSchtasksArg(name), "/f"); err != nil {
+		return fmt.Errorf("failed to delete scheduled task: %w", err)
+	}
+	return nil
+}
+
+// ScheduledTaskRecord is a scheduled task created through a ScheduledTaskTracker
+type ScheduledTaskRecord struct {
+	BID     string    `json:"bid"` // Beacon the task was created through
+	Target  string    `json:"target,omitempty"`
+	Name    string    `json:"name"`
+	Command string    `json:"command"`
+	Created time.Time `json:"created"`
+}
+
+// ScheduledTaskTracker creates scheduled tasks and records them until they
+// are deleted, so an operation can be rolled back. With a path the records
+// are persisted as JSON and survive across processes.
+type ScheduledTaskTracker struct {
+	client  *Client
+	mu      sync.Mutex
+	path    string
+	records []ScheduledTaskRecord
+}
+
+// NewScheduledTaskTracker creates a tracker that keeps its records in memory
+func NewScheduledTaskTracker(client *Client) *ScheduledTaskTracker {
+	return &ScheduledTaskTracker{client: client}
+}
+
+// OpenScheduledTaskTracker creates a tracker persisted at path, loading the
+// records of earlier runs if the file exists
+func OpenScheduledTaskTracker(client *Client, path string) (*ScheduledTaskTracker, error) {
+	t := &ScheduledTaskTracker{client: client, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduled task records: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &t.records); err != nil {
+			return nil, fmt.Errorf("failed to parse scheduled task records: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// Create creates a scheduled task and records it
+func (t *ScheduledTaskTracker) Create(ctx context.Context, bid string, task ScheduledTask) error {
+	if err := t.client.ScheduledTaskCreate(ctx, bid, task); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.records = append(t.records, ScheduledTaskRecord{
+		BID:     bid,
+		Target:  task.Target,
+		Name:    task.Name,
+		Command: task.Command,
+		Created: time.Now(),
+	})
+	return t.save()
+}
+
+// Run triggers a scheduled task immediately
+func (t *ScheduledTaskTracker) Run(ctx context.Context, bid, target, name string) error {
+	return t.client.ScheduledTaskRun(ctx, bid, target, name)
+}
+
+// Delete deletes a scheduled task and drops its record
+func (t *ScheduledTaskTracker) Delete(ctx context.Context, bid, target, name string) error {
+	if err := t.client.ScheduledTaskDelete(ctx, bid, target, name); err != nil {
+		return err
+	}
+	return t.forget(target, name)
+}
+
+// Records returns the scheduled tasks created and not yet deleted, oldest first
+func (t *ScheduledTaskTracker) Records() []ScheduledTaskRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]ScheduledTaskRecord(nil), t.records...)
+}
+
+// Rollback deletes every recorded task, newest first, through the beacon
+// that created it. Tasks that fail to delete stay recorded and their errors
+// are returned together.
+func (t *ScheduledTaskTracker) Rollback(ctx context.Context) error {
+	records := t.Records()
+	var errs []error
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		if err := t.Delete(ctx, r.BID, r.Target, r.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s on %s: %w", r.Name, scheduledTaskHost(r.Target), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *ScheduledTaskTracker) forget(target, name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	kept := t.records[:0]
+	for _, r := range t.records {
+		if !(strings.EqualFold(r.Target, target) && strings.EqualFold(r.Name, name)) {
+			kept = append(kept, r)
+		}
+	}
+	t.records = kept
+	return t.save()
+}
+
+// save persists the records; the caller holds t.mu
+func (t *ScheduledTaskTracker) save() error {
+	if t.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(t.records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled task records: %w", err)
+	}
+	if err := writeFileAtomic(t.path, data); err != nil {
+		return fmt.Errorf("failed to write scheduled task records: %w", err)
+	}
+	return nil
+}
+
+func scheduledTaskHost(target string) string {
+	if target == "" {
+		return "the beacon host"
+	}
+	return target
+}
@@ -0,0 +1,124 @@
+package csclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Screenshots are usually stored as JPEG
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScreenshotDto represents a screenshot in the team server's data model
+type ScreenshotDto struct {
+	ID        string `json:"id"`
+	BID       string `json:"bid"`
+	User      string `json:"user"`
+	Computer  string `json:"computer"`
+	Timestamp int64  `json:"timestamp"` // Milliseconds since the Unix epoch
+	Title     string `json:"title"`
+}
+
+// Time returns the screenshot timestamp
+func (s *ScreenshotDto) Time() time.Time {
+	return time.UnixMilli(s.Timestamp)
+}
+
+// ListScreenshots retrieves all screenshots from the data model
+func (c *Client) ListScreenshots(ctx context.Context) ([]ScreenshotDto, error) {
+	var screenshots []ScreenshotDto
+	if err := c.doRequest(ctx, "GET", "/api/v1/data/screenshots", nil, &screenshots, true); err != nil {
+		return nil, fmt.Errorf("failed to list screenshots: %w", err)
+	}
+	return screenshots, nil
+}
+
+// GetScreenshot streams the image of a screenshot to w in the format stored by the server
+func (c *Client) GetScreenshot(ctx context.Context, id string, w io.Writer) (int64, error) {
+	escaped, err := escapePathParam("screenshot ID", id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get screenshot: %w", err)
+	}
+	n, err := c.doStream(ctx, "/api/v1/data/screenshots/"+escaped, w)
+	if err != nil {
+		return n, fmt.Errorf("failed to get screenshot: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteScreenshot removes a screenshot from the data model
+func (c *Client) DeleteScreenshot(ctx context.Context, id string) error {
+	escaped, err := escapePathParam("screenshot ID", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete screenshot: %w", err)
+	}
+	if err := c.doRequest(ctx, "DELETE", "/api/v1/data/screenshots/"+escaped, nil, nil, true); err != nil {
+		return fmt.Errorf("failed to delete screenshot: %w", err)
+	}
+	return nil
+}
+
+// FindTaskScreenshots returns the screenshots taken by the task's beacon since
+// the task was created, oldest first. Screenshots are not linked to tasks in the
+// data model, so they are matched by beacon and time.
+func (c *Client) FindTaskScreenshots(ctx context.Context, task *TaskSummaryDto) ([]ScreenshotDto, error) {
+	screenshots, err := c.ListScreenshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var matched []ScreenshotDto
+	for _, s := range screenshots {
+		if s.BID == task.BID && !s.Time().Before(task.Created.Truncate(time.Second)) {
+			matched = append(matched, s)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Timestamp < matched[j].Timestamp
+	})
+	return matched, nil
+}
+
+// GetScreenshotPNG decodes a screenshot and writes it to w as PNG
+func (c *Client) GetScreenshotPNG(ctx context.Context, id string, w io.Writer) error {
+	var buf bytes.Buffer
+	if _, err := c.GetScreenshot(ctx, id, &buf); err != nil {
+		return err
+	}
+	img, _, err := image.Decode(&buf)
+	if err != nil {
+		return fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return nil
+}
+
+// SaveScreenshot writes a screenshot to a file. Paths ending in ".png" are
+// converted to PNG; any other extension receives the image as stored (usually JPEG).
+func (c *Client) SaveScreenshot(ctx context.Context, id string, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to save screenshot: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		err = c.GetScreenshotPNG(ctx, id, f)
+	} else {
+		_, err = c.GetScreenshot(ctx, id, f)
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to save screenshot: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package csclient
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// BeaconFilter is a composable beacon predicate, e.g.
+//
+//	csclient.Alive().And(csclient.IsAdmin()).And(csclient.HostMatches("DC*"))
+//
+// A nil BeaconFilter matches every beacon.
+type BeaconFilter func(b *BeaconDto) bool
+
+// Match reports whether the beacon satisfies the filter
+func (f BeaconFilter) Match(b *BeaconDto) bool {
+	return f == nil || f(b)
+}
+
+// And returns a filter matching beacons that satisfy both filters
+func (f BeaconFilter) And(g BeaconFilter) BeaconFilter {
+	return func(b *BeaconDto) bool { return f.Match(b) && g.Match(b) }
+}
+
+// Or returns a filter matching beacons that satisfy either filter
+func (f BeaconFilter) Or(g BeaconFilter) BeaconFilter {
+	return func(b *BeaconDto) bool { return f.Match(b) || g.Match(b) }
+}
+
+// Not returns a filter matching beacons that do not satisfy f
+func (f BeaconFilter) Not() BeaconFilter {
+	return func(b *BeaconDto) bool { return !f.Match(b) }
+}
+
+// Apply returns the beacons that satisfy the filter
+func (f BeaconFilter) Apply(beacons []BeaconDto) []BeaconDto {
+	var matched []BeaconDto
+	for i := range beacons {
+		if f.Match(&beacons[i]) {
+			matched = append(matched, beacons[i])
+		}
+	}
+	return matched
+}
+
+// Filter converts the query into a BeaconFilter
+func (q BeaconQuery) Filter() BeaconFilter {
+	return func(b *BeaconDto) bool { return q.Matches(b) }
+}
+
+// AnyBeacon matches every beacon
+func AnyBeacon() BeaconFilter {
+	return func(*BeaconDto) bool { return true }
+}
+
+// Alive matches beacons that are alive
+func Alive() BeaconFilter {
+	return func(b *BeaconDto) bool { return b.Alive }
+}
+
+// IsAdmin matches beacons running with administrative rights
+func IsAdmin() BeaconFilter {
+	return func(b *BeaconDto) bool { return b.IsAdmin }
+}
+
+// BeaconIDs matches beacons with one of the given IDs
+func BeaconIDs(bids ...string) BeaconFilter {
+	set := make(map[string]bool, len(bids))
+	for _, bid := range bids {
+		set[bid] = true
+	}
+	return func(b *BeaconDto) bool { return set[b.BID] }
+}
+
+// HostMatches matches the computer name against a case-insensitive glob (* and ?)
+func HostMatches(pattern string) BeaconFilter {
+	return func(b *BeaconDto) bool { return globMatch(pattern, b.Computer) }
+}
+
+// UserMatches matches the beacon user against a case-insensitive glob (* and ?)
+func UserMatches(pattern string) BeaconFilter {
+	return func(b *BeaconDto) bool { return globMatch(pattern, b.User) }
+}
+
+// ProcessMatches matches the process name against a case-insensitive glob (* and ?)
+func ProcessMatches(pattern string) BeaconFilter {
+	return func(b *BeaconDto) bool { return globMatch(pattern, b.Process) }
+}
+
+// OSMatches matches the OS name against a case-insensitive glob (* and ?)
+func OSMatches(pattern string) BeaconFilter {
+	return func(b *BeaconDto) bool { return globMatch(pattern, b.OS) }
+}
+
+// ListenerIs matches beacons on the named listener
+func ListenerIs(name string) BeaconFilter {
+	return func(b *BeaconDto) bool { return strings.EqualFold(b.Listener, name) }
+}
+
+// BeaconArchIs matches beacons of the given architecture ("x86" or "x64")
+func BeaconArchIs(arch string) BeaconFilter {
+	return func(b *BeaconDto) bool { return strings.EqualFold(b.BeaconArch, arch) }
+}
+
+// SessionIs matches beacons of the given session type
+func SessionIs(t SessionType) BeaconFilter {
+	return func(b *BeaconDto) bool { return b.SessionType() == t }
+}
+
+// InSubnet matches beacons whose internal address is in the CIDR.
+// An invalid CIDR matches nothing.
+func InSubnet(cidr string) BeaconFilter {
+	_, network, err := net.ParseCIDR(cidr)
+	return func(b *BeaconDto) bool {
+		if err != nil {
+			return false
+		}
+		ip := net.ParseIP(b.Internal)
+		return ip != nil && network.Contains(ip)
+	}
+}
+
+// SelectBeacons lists beacons and returns those matching the filter
+func (c *Client) SelectBeacons(ctx context.Context, filter BeaconFilter) ([]BeaconDto, error) {
+	beacons, err := c.ListBeacons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(beacons), nil
+}
+
+// globMatch reports whether s matches a case-insensitive pattern where
+// '*' matches any run of characters and '?' matches a single character
+func globMatch(pattern, s string) bool {
+	p := []rune(strings.ToLower(pattern))
+	r := []rune(strings.ToLower(s))
+
+	pi, ri := 0, 0
+	star, mark := -1, 0
+	for ri < len(r) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == r[ri]):
+			pi++
+			ri++
+		case pi < len(p) && p[pi] == '*':
+			star = pi
+			mark = ri
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			ri = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
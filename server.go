@@ -0,0 +1,128 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServerInfo describes the team server as reported by the system information endpoint
+type ServerInfo struct {
+	Version       string            // Team server version
+	APIVersion    string            // REST API version
+	LicenseExpiry string            // License expiration date as reported by the server
+	Properties    map[string]string // All key/value pairs found in the report
+	Raw           string            // Unparsed system information report
+}
+
+// ServerInfo retrieves the team server version, API version and license expiry
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var raw string
+	if err := c.doRequest(ctx, "GET", "/api/v1/config/systeminformation", nil, &raw, true); err != nil {
+		return nil, fmt.Errorf("failed to get server info: %w", err)
+	}
+	return parseServerInfo(raw), nil
+}
+
+// Ping performs a lightweight health check against the API root.
+// Unlike other calls it is never retried, so a dead server is reported immediately.
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := c.withOperationTimeout(ctx, "/api/v1")
+	defer cancel()
+
+	var root string
+	if err := c.doRequestOnce(ctx, "GET", "/api/v1", nil, &root, true); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// AtLeast reports whether the team server version is greater than or equal to
+// the given dotted version (e.g. "4.12"). Unknown versions never satisfy the check.
+func (s *ServerInfo) AtLeast(version string) bool {
+	if s == nil || s.Version == "" {
+		return false
+	}
+	return compareVersions(s.Version, version) >= 0
+}
+
+// parseServerInfo extracts known fields from a "key: value" style report
+func parseServerInfo(raw string) *ServerInfo {
+	info := &ServerInfo{
+		Properties: make(map[string]string),
+		Raw:        raw,
+	}
+
+	for _, line := range strings.Split(raw, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			key, value, ok = strings.Cut(line, "=")
+		}
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			continue
+		}
+		info.Properties[key] = value
+
+		lower := strings.ToLower(key)
+		switch {
+		case strings.Contains(lower, "api") && strings.Contains(lower, "version"):
+			info.APIVersion = value
+		case strings.Contains(lower, "version") && info.Version == "":
+			info.Version = value
+		case strings.Contains(lower, "expir"):
+			info.LicenseExpiry = value
+		}
+	}
+
+	return info
+}
+
+// compareVersions compares the numeric components of two dotted versions,
+// ignoring any non-numeric suffix (e.g. "4.12-beta" compares as "4.12")
+func compareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version string into its leading numeric components
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		end := 0
+		for end < len(field) && field[end] >= '0' && field[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(field[:end])
+		parts = append(parts, n)
+		if end < len(field) {
+			break
+		}
+	}
+	return parts
+}
@@ -0,0 +1,164 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Service start types accepted by ServiceConfig.StartType
+const (
+	ServiceStartDemand   = "demand"
+	ServiceStartAuto     = "auto"
+	ServiceStartDisabled = "disabled"
+)
+
+// ServiceConfig describes a service to create
+type ServiceConfig struct {
+	Name        string
+	DisplayName string // Optional
+	BinaryPath  string // Command line of the service binary
+	StartType   string // ServiceStartDemand (default), ServiceStartAuto or ServiceStartDisabled
+}
+
+// ServiceError reports an "[SC] <Operation> FAILED <code>" result from sc.exe
+type ServiceError struct {
+	Operation string // Service control API that failed, e.g. OpenService
+	Code      int    // Win32 error code, e.g. 1060 for a missing service
+	Message   string
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("%s failed with error %d: %s", e.Operation, e.Code, e.Message)
+}
+
+// scFailedRe matches the failure line of sc.exe, which is followed by the error message
+var scFailedRe = regexp.MustCompile(`\[SC\]\s+(\w+)\s+FAILED\s+(\d+):`)
+
+// sc runs sc.exe against target (empty for the beacon's host) without
+// cmd.exe, waits for it and returns its output or the failure it reported
+func (c *Client) sc(ctx context.Context, bid, target string, args ...string) (string, error) {
+	command := "sc.exe"
+	if target != "" {
+		if err := validateRemoteHost(target); err != nil {
+			return "", err
+		}
+		command += ` \\` + strings.TrimPrefix(target, `\\`)
+	}
+	for _, arg := range args {
+		command += " " + arg
+	}
+	output, err := c.runOutput(ctx, bid, command)
+	if err != nil {
+		return "", err
+	}
+	if loc := scFailedRe.FindStringSubmatchIndex(output); loc != nil {
+		code, _ := strconv.Atoi(output[loc[4]:loc[5]])
+		svcErr := &ServiceError{Operation: output[loc[2]:loc[3]], Code: code}
+		for _, line := range outputLines(output[loc[1]:]) {
+			if line = strings.TrimSpace(line); line != "" {
+				svcErr.Message = line
+				break
+			}
+		}
+		return "", svcErr
+	}
+	return output, nil
+}
+
+// validateServiceArg rejects values that cannot be passed to sc.exe on one command line
+func validateServiceArg(field, value string) error {
+	if value == "" {
+		return &ValidationError{Field: field, Value: value, Reason: "must not be empty"}
+	}
+	if strings.ContainsAny(value, "\"\r\n") {
+		return &ValidationError{Field: field, Value: value, Reason: "must not contain quotes or newlines"}
+	}
+	return nil
+}
+
+// ServiceQuery returns the status of a service on target (empty for the
+// beacon's host), or of every service when name is empty
+func (c *Client) ServiceQuery(ctx context.Context, bid, target, name string) ([]ServiceStatus, error) {
+	args := []string{"queryex", "type=", "service", "state=", "all"}
+	if name != "" {
+		if err := validateServiceArg("name", name); err != nil {
+			return nil, fmt.Errorf("failed to query service: %w", err)
+		}
+		args = []string{"queryex", `"` + name + `"`}
+	}
+	output, err := c.sc(ctx, bid, target, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query service: %w", err)
+	}
+	return ParseServiceStatus(output), nil
+}
+
+// ServiceCreate creates a service on target (empty for the beacon's host)
+func (c *Client) ServiceCreate(ctx context.Context, bid, target string, svc ServiceConfig) error {
+	if err := validateServiceArg("name", svc.Name); err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	if err := validateServiceArg("binaryPath", svc.BinaryPath); err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	start := svc.StartType
+	if start == "" {
+		start = ServiceStartDemand
+	}
+	if start != ServiceStartDemand && start != ServiceStartAuto && start != ServiceStartDisabled {
+		return fmt.Errorf("failed to create service: %w", &ValidationError{Field: "startType", Value: start, Reason: "must be demand, auto or disabled"})
+	}
+
+	args := []string{"create", `"` + svc.Name + `"`, "binPath=", `"` + svc.BinaryPath + `"`, "start=", start}
+	if svc.DisplayName != "" {
+		if err := validateServiceArg("displayName", svc.DisplayName); err != nil {
+			return fmt.Errorf("failed to create service: %w", err)
+		}
+		args = append(args, "DisplayName=", `"`+svc.DisplayName+`"`)
+	}
+	if _, err := c.sc(ctx, bid, target, args...); err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	return nil
+}
+
+// ServiceStart starts a service on target and returns the state sc.exe reports.
+// Services whose binary is not a real service (e.g. a payload that never
+// reports to the service control manager) fail with error 1053 after running.
+func (c *Client) ServiceStart(ctx context.Context, bid, target, name string) (*ServiceStatus, error) {
+	return c.serviceControl(ctx, bid, target, "start", name)
+}
+
+// ServiceStop stops a service on target and returns the state sc.exe reports
+func (c *Client) ServiceStop(ctx context.Context, bid, target, name string) (*ServiceStatus, error) {
+	return c.serviceControl(ctx, bid, target, "stop", name)
+}
+
+func (c *Client) serviceControl(ctx context.Context, bid, target, op, name string) (*ServiceStatus, error) {
+	if err := validateServiceArg("name", name); err != nil {
+		return nil, fmt.Errorf("failed to %s service: %w", op, err)
+	}
+	output, err := c.sc(ctx, bid, target, op, `"`+name+`"`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s service: %w", op, err)
+	}
+	statuses := ParseServiceStatus(output)
+	if len(statuses) == 0 {
+		return &ServiceStatus{Name: name}, nil
+	}
+	return &statuses[0], nil
+}
+
+// ServiceDelete marks a service on target for deletion
+func (c *Client) ServiceDelete(ctx context.Context, bid, target, name string) error {
+	if err := validateServiceArg("name", name); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if _, err := c.sc(ctx, bid, target, "delete", `"`+name+`"`); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	return nil
+}
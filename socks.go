@@ -0,0 +1,158 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SOCKS server versions accepted by SocksStart
+const (
+	SocksVersion4 = "4"
+	SocksVersion5 = "5"
+)
+
+// Socks4StartDto starts a SOCKS4a server on the team server
+type Socks4StartDto struct {
+	Port int `json:"port"`
+}
+
+// SocksAuthDto holds SOCKS5 username/password credentials
+type SocksAuthDto struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// Socks5StartDto starts a SOCKS5 server on the team server
+type Socks5StartDto struct {
+	Port          int           `json:"port"`
+	Auth          *SocksAuthDto `json:"auth,omitempty"`
+	EnableLogging bool          `json:"enableLogging,omitempty"`
+}
+
+// SocksPivot is a SOCKS server started through this client
+type SocksPivot struct {
+	BID     string
+	Port    int
+	Version string
+	Auth    bool
+	Started time.Time
+}
+
+// socksTable records the SOCKS servers started through a client, keyed by
+// team server port. The API has no call listing active SOCKS servers.
+type socksTable struct {
+	mu     sync.Mutex
+	pivots map[int]SocksPivot
+}
+
+func (t *socksTable) add(p SocksPivot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pivots == nil {
+		t.pivots = make(map[int]SocksPivot)
+	}
+	t.pivots[p.Port] = p
+}
+
+func (t *socksTable) remove(bid string, port int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for p, pivot := range t.pivots {
+		if pivot.BID == bid && (port == 0 || p == port) {
+			delete(t.pivots, p)
+		}
+	}
+}
+
+// SocksStart starts a SOCKS server on the team server port that tunnels through
+// the beacon. version is SocksVersion4 or SocksVersion5 (the default); user and
+// pass enable SOCKS5 authentication.
+func (c *Client) SocksStart(ctx context.Context, bid string, port int, version, user, pass string) (*AsyncCommandResponse, error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("failed to start SOCKS server: %w", &ValidationError{Field: "port", Value: strconv.Itoa(port), Reason: "must be between 1 and 65535"})
+	}
+	if version == "" {
+		version = SocksVersion5
+	}
+
+	var endpoint string
+	var req interface{}
+	switch version {
+	case SocksVersion4:
+		if user != "" || pass != "" {
+			return nil, fmt.Errorf("failed to start SOCKS server: %w", &ValidationError{Field: "user", Value: user, Reason: "SOCKS4 does not support authentication"})
+		}
+		endpoint, req = "/execute/socks4Start", Socks4StartDto{Port: port}
+	case SocksVersion5:
+		dto := Socks5StartDto{Port: port}
+		if user != "" || pass != "" {
+			if user == "" || pass == "" {
+				return nil, fmt.Errorf("failed to start SOCKS server: %w", &ValidationError{Field: "user", Value: user, Reason: "user and password must be set together"})
+			}
+			dto.Auth = &SocksAuthDto{User: user, Password: pass}
+		}
+		endpoint, req = "/execute/socks5Start", dto
+	default:
+		return nil, fmt.Errorf("failed to start SOCKS server: %w", &ValidationError{Field: "version", Value: version, Reason: "must be 4 or 5"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SOCKS server: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to start SOCKS server: %w", err)
+	}
+	c.socks.add(SocksPivot{BID: bid, Port: port, Version: version, Auth: user != "", Started: time.Now()})
+	return &resp, nil
+}
+
+// SocksStop stops the beacon's SOCKS server on port
+func (c *Client) SocksStop(ctx context.Context, bid string, port int) (*AsyncCommandResponse, error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("failed to stop SOCKS server: %w", &ValidationError{Field: "port", Value: strconv.Itoa(port), Reason: "must be between 1 and 65535"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/socksStop/"+strconv.Itoa(port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop SOCKS server: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to stop SOCKS server: %w", err)
+	}
+	c.socks.remove(bid, port)
+	return &resp, nil
+}
+
+// SocksStopAll stops every SOCKS server tunneling through the beacon
+func (c *Client) SocksStopAll(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/socksStop/all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop SOCKS servers: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to stop SOCKS servers: %w", err)
+	}
+	c.socks.remove(bid, 0)
+	return &resp, nil
+}
+
+// SocksPivots returns the SOCKS servers started through this client and not
+// yet stopped, ordered by port. Servers started by other clients or operators,
+// or torn down with their beacon, are not known to it.
+func (c *Client) SocksPivots() []SocksPivot {
+	c.socks.mu.Lock()
+	defer c.socks.mu.Unlock()
+	pivots := make([]SocksPivot, 0, len(c.socks.pivots))
+	for _, p := range c.socks.pivots {
+		pivots = append(pivots, p)
+	}
+	sort.Slice(pivots, func(i, j int) bool { return pivots[i].Port < pivots[j].Port })
+	return pivots
+}
@@ -0,0 +1,144 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// SpawnDto spawns a session for a listener
+type SpawnDto struct {
+	Listener string `json:"listener"`
+	Arch     string `json:"arch,omitempty"`
+}
+
+// validateArch accepts "x86", "x64" or empty (the beacon's default)
+func validateArch(arch string) error {
+	switch arch {
+	case "", "x86", "x64":
+		return nil
+	}
+	return &ValidationError{Field: "arch", Value: arch, Reason: "must be x86 or x64"}
+}
+
+// validateTargetArch accepts "x86" or "x64", the architecture of a target process
+func validateTargetArch(arch string) error {
+	if arch == "" {
+		return &ValidationError{Field: "arch", Value: arch, Reason: "must be x86 or x64"}
+	}
+	return validateArch(arch)
+}
+
+// SpawnSession spawns a process and injects a payload for listener, passing the
+// session to another listener or team server. An empty arch uses the beacon's default.
+func (c *Client) SpawnSession(ctx context.Context, bid, listener string, arch string) (*AsyncCommandResponse, error) {
+	if listener == "" {
+		return nil, fmt.Errorf("failed to spawn session: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+	if err := validateArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to spawn session: %w", err)
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spawn session: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, SpawnDto{Listener: listener, Arch: arch}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to spawn session: %w", err)
+	}
+	return &resp, nil
+}
+
+// SpawnBeaconAsDto spawns a session as another user
+type SpawnBeaconAsDto struct {
+	Domain   string `json:"domain,omitempty"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Listener string `json:"listener"`
+}
+
+// SpawnuDto spawns a session under a parent process
+type SpawnuDto struct {
+	PID      int    `json:"pid"`
+	Listener string `json:"listener"`
+}
+
+// Spawn spawns a session for listener (the spawn console command); see SpawnSession
+func (c *Client) Spawn(ctx context.Context, bid, listener, arch string) (*AsyncCommandResponse, error) {
+	return c.SpawnSession(ctx, bid, listener, arch)
+}
+
+// SpawnAs spawns a session for listener as another user. An empty domain means
+// the local machine.
+func (c *Client) SpawnAs(ctx context.Context, bid, domain, user, password, listener string) (*AsyncCommandResponse, error) {
+	if user == "" {
+		return nil, fmt.Errorf("failed to spawn as user: %w", &ValidationError{Field: "user", Value: user, Reason: "must not be empty"})
+	}
+	if listener == "" {
+		return nil, fmt.Errorf("failed to spawn as user: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/beacon/asUser")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spawn as user: %w", err)
+	}
+	req := SpawnBeaconAsDto{Domain: domain, User: user, Password: password, Listener: listener}
+	if err := c.doRequest(ctx, "POST", path, req, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to spawn as user: %w", err)
+	}
+	return &resp, nil
+}
+
+// SpawnU spawns a session for listener as a child of process pid
+func (c *Client) SpawnU(ctx context.Context, bid string, pid int, listener string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to spawn under process: %w", err)
+	}
+	if listener == "" {
+		return nil, fmt.Errorf("failed to spawn under process: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/spawn/beacon/under")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spawn under process: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, SpawnuDto{PID: pid, Listener: listener}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to spawn under process: %w", err)
+	}
+	return &resp, nil
+}
+
+// InjectDto injects a session into a process
+type InjectDto struct {
+	PID      int    `json:"pid"`
+	Arch     string `json:"arch"`
+	Listener string `json:"listener"`
+}
+
+// Inject injects a session for listener into the running process pid of the given architecture
+func (c *Client) Inject(ctx context.Context, bid string, pid int, arch, listener string) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to inject session: %w", err)
+	}
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to inject session: %w", err)
+	}
+	if listener == "" {
+		return nil, fmt.Errorf("failed to inject session: %w", &ValidationError{Field: "listener", Value: listener, Reason: "must not be empty"})
+	}
+	if err := c.preflightInject(ctx, bid, "session", pid, arch); err != nil {
+		return nil, fmt.Errorf("failed to inject session: %w", err)
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/inject/beacon")
+	if err != nil {
+		return nil, fmt.Errorf("failed to inject session: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, InjectDto{PID: pid, Arch: arch, Listener: listener}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to inject session: %w", err)
+	}
+	return &resp, nil
+}
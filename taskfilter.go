@@ -0,0 +1,112 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TaskFilter narrows task listings. Filters are sent to the server as query
+// parameters and also applied client-side, so results are correct whether or
+// not the server honours them. Zero-value fields do not filter.
+type TaskFilter struct {
+	Statuses        []TaskStatus // Match any of these statuses
+	User            string       // Operator that issued the task (case-insensitive)
+	CommandContains string       // Substring of the task command (case-insensitive)
+	CreatedSince    time.Time    // Only tasks created at or after this time
+}
+
+// IsZero reports whether the filter matches every task
+func (f TaskFilter) IsZero() bool {
+	return len(f.Statuses) == 0 && f.User == "" && f.CommandContains == "" && f.CreatedSince.IsZero()
+}
+
+// Matches reports whether a task satisfies the filter
+func (f TaskFilter) Matches(task *TaskSummaryDto) bool {
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, status := range f.Statuses {
+			if task.TaskStatus == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.User != "" && !strings.EqualFold(task.User, f.User) {
+		return false
+	}
+	if f.CommandContains != "" && !strings.Contains(strings.ToLower(task.TaskCommand), strings.ToLower(f.CommandContains)) {
+		return false
+	}
+	if !f.CreatedSince.IsZero() && task.Created.Before(f.CreatedSince) {
+		return false
+	}
+	return true
+}
+
+// query returns the filter as query parameters
+func (f TaskFilter) query() url.Values {
+	q := url.Values{}
+	for _, status := range f.Statuses {
+		q.Add("status", string(status))
+	}
+	if f.User != "" {
+		q.Set("user", f.User)
+	}
+	if f.CommandContains != "" {
+		q.Set("command", f.CommandContains)
+	}
+	if !f.CreatedSince.IsZero() {
+		q.Set("createdSince", f.CreatedSince.UTC().Format(time.RFC3339))
+	}
+	return q
+}
+
+// apply returns the tasks that match the filter
+func (f TaskFilter) apply(tasks []TaskSummaryDto) []TaskSummaryDto {
+	if f.IsZero() {
+		return tasks
+	}
+	matched := tasks[:0]
+	for i := range tasks {
+		if f.Matches(&tasks[i]) {
+			matched = append(matched, tasks[i])
+		}
+	}
+	return matched
+}
+
+// withQuery appends encoded query parameters to a path
+func withQuery(path string, q url.Values) string {
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
+
+// ListTasksFiltered retrieves the tasks matching the filter
+func (c *Client) ListTasksFiltered(ctx context.Context, filter TaskFilter) ([]TaskSummaryDto, error) {
+	var tasks []TaskSummaryDto
+	if err := c.doRequest(ctx, "GET", withQuery("/api/v1/tasks", filter.query()), nil, &tasks, true); err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	return filter.apply(tasks), nil
+}
+
+// GetBeaconTasksSummaryFiltered retrieves the task summaries of a beacon matching the filter
+func (c *Client) GetBeaconTasksSummaryFiltered(ctx context.Context, bid string, filter TaskFilter) ([]TaskSummaryDto, error) {
+	var tasks []TaskSummaryDto
+	path, err := beaconPath(bid, "/tasks/summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon tasks: %w", err)
+	}
+	if err := c.doRequest(ctx, "GET", withQuery(path, filter.query()), nil, &tasks, true); err != nil {
+		return nil, fmt.Errorf("failed to get beacon tasks: %w", err)
+	}
+	return filter.apply(tasks), nil
+}
@@ -3,16 +3,22 @@ package csclient
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 )
 
 // GetTask retrieves detailed information about a specific task
 func (c *Client) GetTask(ctx context.Context, taskID string) (*TaskDetailDto, error) {
 	var task TaskDetailDto
-	path := fmt.Sprintf("/api/v1/tasks/%s", taskID)
+	path, err := taskPath(taskID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
 	if err := c.doRequest(ctx, "GET", path, nil, &task, true); err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
+	c.duplicates.finished(&task.TaskSummaryDto)
 	return &task, nil
 }
 
@@ -22,13 +28,21 @@ func (c *Client) ListTasks(ctx context.Context) ([]TaskSummaryDto, error) {
 	if err := c.doRequest(ctx, "GET", "/api/v1/tasks", nil, &tasks, true); err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
+	if c.duplicates != nil {
+		for i := range tasks {
+			c.duplicates.finished(&tasks[i])
+		}
+	}
 	return tasks, nil
 }
 
 // GetBeaconTasksSummary retrieves task summaries for a specific beacon
 func (c *Client) GetBeaconTasksSummary(ctx context.Context, bid string) ([]TaskSummaryDto, error) {
 	var tasks []TaskSummaryDto
-	path := fmt.Sprintf("/api/v1/beacons/%s/tasks/summary", bid)
+	path, err := beaconPath(bid, "/tasks/summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon tasks: %w", err)
+	}
 	if err := c.doRequest(ctx, "GET", path, nil, &tasks, true); err != nil {
 		return nil, fmt.Errorf("failed to get beacon tasks: %w", err)
 	}
@@ -38,17 +52,23 @@ func (c *Client) GetBeaconTasksSummary(ctx context.Context, bid string) ([]TaskS
 // GetBeaconTasksDetail retrieves detailed tasks for a specific beacon
 func (c *Client) GetBeaconTasksDetail(ctx context.Context, bid string) ([]TaskDetailDto, error) {
 	var tasks []TaskDetailDto
-	path := fmt.Sprintf("/api/v1/beacons/%s/tasks/detail", bid)
+	path, err := beaconPath(bid, "/tasks/detail")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon task details: %w", err)
+	}
 	if err := c.doRequest(ctx, "GET", path, nil, &tasks, true); err != nil {
 		return nil, fmt.Errorf("failed to get beacon task details: %w", err)
 	}
 	return tasks, nil
 }
 
+// taskPollInterval is the delay between task status polls
+const taskPollInterval = 2 * time.Second
+
 // WaitForTaskCompletion polls a task until it completes or times out
 func (c *Client) WaitForTaskCompletion(ctx context.Context, taskID string, timeout time.Duration) (*TaskDetailDto, error) {
 	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(taskPollInterval)
 	defer ticker.Stop()
 
 	for {
@@ -65,11 +85,391 @@ func (c *Client) WaitForTaskCompletion(ctx context.Context, taskID string, timeo
 				return nil, err
 			}
 
-			if task.TaskStatus == TaskStatusCompleted ||
-			   task.TaskStatus == TaskStatusOutputReceived ||
-			   task.TaskStatus == TaskStatusFailed {
+			if task.TaskStatus.IsTerminal() {
 				return task, nil
 			}
 		}
 	}
 }
+
+// TaskUpdate is emitted by WatchTask when a task changes
+type TaskUpdate struct {
+	Task       *TaskDetailDto           // Latest task state
+	Previous   TaskStatus               // Status before this update (empty for the first update)
+	NewResults []map[string]interface{} // Result entries received since the previous update
+	NewErrors  []ErrorMessageDto        // Error entries received since the previous update
+	Err        error                    // Set when polling failed; the channel is closed afterwards
+}
+
+// WatchTask polls a task and emits an update for every status transition or new
+// output until the task is COMPLETED or FAILED, polling fails or ctx ends. It
+// keeps polling while the task is in OUTPUT_RECEIVED, so output appended later
+// is still reported. The channel is closed when watching stops.
+func (c *Client) WatchTask(ctx context.Context, taskID string) (<-chan TaskUpdate, error) {
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan TaskUpdate)
+	go func() {
+		defer close(updates)
+
+		var status TaskStatus
+		seenResults, seenErrors := 0, 0
+		ticker := time.NewTicker(taskPollInterval)
+		defer ticker.Stop()
+
+		for {
+			if task.TaskStatus != status || len(task.Result) > seenResults || len(task.Error) > seenErrors {
+				update := TaskUpdate{Task: task, Previous: status}
+				if len(task.Result) > seenResults {
+					update.NewResults = task.Result[seenResults:]
+					seenResults = len(task.Result)
+				}
+				if len(task.Error) > seenErrors {
+					update.NewErrors = task.Error[seenErrors:]
+					seenErrors = len(task.Error)
+				}
+				status = task.TaskStatus
+
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if task.TaskStatus == TaskStatusCompleted || task.TaskStatus == TaskStatusFailed {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next, err := c.GetTask(ctx, taskID)
+			if err != nil {
+				select {
+				case updates <- TaskUpdate{Task: task, Previous: status, Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			task = next
+		}
+	}()
+
+	return updates, nil
+}
+
+// WaitOption configures WaitForTasks
+type WaitOption func(*waitConfig)
+
+// waitConfig holds the settings used by WaitForTasks
+type waitConfig struct {
+	pollInterval time.Duration
+	rate         float64
+	concurrency  int
+	timeout      time.Duration
+}
+
+// WithPollInterval sets the delay between polling rounds
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) { cfg.pollInterval = d }
+}
+
+// WithRateLimit caps task status requests per second across all tasks being waited on
+func WithRateLimit(requestsPerSecond float64) WaitOption {
+	return func(cfg *waitConfig) { cfg.rate = requestsPerSecond }
+}
+
+// WithConcurrency caps the number of in-flight task status requests
+func WithConcurrency(n int) WaitOption {
+	return func(cfg *waitConfig) { cfg.concurrency = n }
+}
+
+// WithWaitTimeout bounds the total time spent waiting
+func WithWaitTimeout(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) { cfg.timeout = d }
+}
+
+// WaitForTasks polls several tasks concurrently under a shared rate limit and
+// returns once all of them reach a terminal status. If ctx ends or polling a
+// task fails, the tasks that already finished are returned with the error.
+func (c *Client) WaitForTasks(ctx context.Context, taskIDs []string, opts ...WaitOption) (map[string]*TaskDetailDto, error) {
+	cfg := waitConfig{
+		pollInterval: taskPollInterval,
+		rate:         10,
+		concurrency:  8,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
+	}
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	var limiter <-chan time.Time
+	if cfg.rate > 0 {
+		// Rates above one per nanosecond would truncate the interval to zero
+		interval := time.Duration(float64(time.Second) / cfg.rate)
+		if interval < 1 {
+			interval = 1
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	done := make(map[string]*TaskDetailDto, len(taskIDs))
+	seen := make(map[string]bool, len(taskIDs))
+	pending := make([]string, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		if !seen[id] {
+			seen[id] = true
+			pending = append(pending, id)
+		}
+	}
+
+	for len(pending) > 0 {
+		var (
+			mu       sync.Mutex
+			wg       sync.WaitGroup
+			firstErr error
+			still    []string
+		)
+		sem := make(chan struct{}, cfg.concurrency)
+
+		for _, id := range pending {
+			if limiter != nil {
+				select {
+				case <-ctx.Done():
+				case <-limiter:
+				}
+			}
+			if ctx.Err() != nil {
+				break
+			}
+
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				task, err := c.GetTask(ctx, id)
+
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = fmt.Errorf("task %s: %w", id, err)
+					}
+				case task.TaskStatus.IsTerminal():
+					done[id] = task
+				default:
+					still = append(still, id)
+				}
+			}(id)
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			return done, ctx.Err()
+		}
+		if firstErr != nil {
+			return done, firstErr
+		}
+		pending = still
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return done, ctx.Err()
+		case <-time.After(cfg.pollInterval):
+		}
+	}
+
+	return done, nil
+}
+
+// OutputEntry is a single task result entry delivered by StreamTaskOutput
+type OutputEntry struct {
+	Index     int                    // Position of the entry in the task's result array
+	Type      OutputType             // Output type discriminator
+	Timestamp time.Time              // When the output was produced
+	Text      string                 // Output text for text entries
+	Raw       map[string]interface{} // Untyped entry, for use with DecodeTaskResult style decoding
+	Err       error                  // Set when polling failed; the channel is closed afterwards
+}
+
+// StreamTaskOutput delivers only the result entries added since the previous poll.
+// Like WatchTask it keeps polling while the task is in OUTPUT_RECEIVED, so it
+// suits long-running jobs (keyloggers, port scans) that produce output continuously.
+// The channel is closed once the task is COMPLETED or FAILED, polling fails or ctx ends.
+func (c *Client) StreamTaskOutput(ctx context.Context, taskID string) (<-chan OutputEntry, error) {
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan OutputEntry)
+	go func() {
+		defer close(entries)
+
+		offset := 0
+		ticker := time.NewTicker(taskPollInterval)
+		defer ticker.Stop()
+
+		for {
+			for ; offset < len(task.Result); offset++ {
+				select {
+				case entries <- newOutputEntry(offset, task.Result[offset]):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if task.TaskStatus == TaskStatusCompleted || task.TaskStatus == TaskStatusFailed {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next, err := c.GetTask(ctx, taskID)
+			if err != nil {
+				select {
+				case entries <- OutputEntry{Index: offset, Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			task = next
+		}
+	}()
+
+	return entries, nil
+}
+
+// newOutputEntry converts an untyped result entry into an OutputEntry
+func newOutputEntry(index int, raw map[string]interface{}) OutputEntry {
+	entry := OutputEntry{
+		Index: index,
+		Type:  ResultOutputType(raw),
+		Raw:   raw,
+	}
+	if ts, ok := raw["timestamp"].(string); ok {
+		entry.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+	}
+	if entry.Type == OutputTypeText {
+		entry.Text, _ = raw["output"].(string)
+	}
+	return entry
+}
+
+// CancelOption configures CancelTask
+type CancelOption func(*cancelConfig)
+
+// cancelConfig holds the settings used by CancelTask
+type cancelConfig struct {
+	clearQueue bool
+}
+
+// WithClearQueue lets CancelTask proceed when other commands are queued for
+// the same beacon, aborting them along with the task
+func WithClearQueue() CancelOption {
+	return func(cfg *cancelConfig) { cfg.clearQueue = true }
+}
+
+// CancelTask aborts a task that has not yet been delivered to its beacon.
+// The API can only clear a beacon's whole queue, so CancelTask refuses, listing
+// the other queued task IDs, when the task is not the only command queued for
+// its beacon, unless WithClearQueue is given.
+func (c *Client) CancelTask(ctx context.Context, taskID string, opts ...CancelOption) (*AsyncCommandResponse, error) {
+	var cfg cancelConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel task: %w", err)
+	}
+	if task.TaskStatus.IsTerminal() {
+		return nil, fmt.Errorf("failed to cancel task: task %s already finished with status %s", taskID, task.TaskStatus)
+	}
+	if len(task.TaskAcknowledgements) > 0 {
+		return nil, fmt.Errorf("failed to cancel task: task %s was already delivered to beacon %s", taskID, task.BID)
+	}
+	if !cfg.clearQueue {
+		queue, err := c.GetBeaconQueue(ctx, task.BID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel task: %w", err)
+		}
+		var others []string
+		for _, queued := range queue {
+			if queued.TaskID != taskID {
+				others = append(others, queued.TaskID)
+			}
+		}
+		if len(others) > 0 {
+			return nil, fmt.Errorf("failed to cancel task: beacon %s also has queued tasks %s that would be aborted; use WithClearQueue to cancel them too", task.BID, strings.Join(others, ", "))
+		}
+	}
+	return c.CancelAll(ctx, task.BID)
+}
+
+// CancelAll aborts every command queued for the beacon (see ClearBeaconQueue)
+func (c *Client) CancelAll(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	return c.ClearBeaconQueue(ctx, bid)
+}
+
+// RerunTask resubmits a previous task's command to the same beacon
+func (c *Client) RerunTask(ctx context.Context, taskID string) (*AsyncCommandResponse, error) {
+	return c.RerunTaskOn(ctx, taskID, "")
+}
+
+// RerunTaskOn resubmits a previous task's command to the given beacon
+// (or the original beacon when bid is empty). Commands that referenced
+// uploaded files cannot be rerun because the file content is not retained.
+func (c *Client) RerunTaskOn(ctx context.Context, taskID string, bid string) (*AsyncCommandResponse, error) {
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerun task: %w", err)
+	}
+	cmd, err := TaskCommandDto(&task.TaskSummaryDto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerun task: %w", err)
+	}
+	if bid == "" {
+		bid = task.BID
+	}
+	return c.ExecuteConsoleCommand(ctx, bid, cmd)
+}
+
+// TaskCommandDto reconstructs the console command of a task from its command line
+func TaskCommandDto(task *TaskSummaryDto) (CommandDto, error) {
+	line := strings.TrimSpace(task.TaskCommand)
+	if line == "" {
+		return CommandDto{}, fmt.Errorf("task %s has no command", task.TaskID)
+	}
+	if strings.Contains(line, "@files/") {
+		return CommandDto{}, fmt.Errorf("task %s references uploaded files which are not retained", task.TaskID)
+	}
+	command, arguments, _ := strings.Cut(line, " ")
+	return CommandDto{Command: command, Arguments: strings.TrimSpace(arguments)}, nil
+}
@@ -0,0 +1,147 @@
+package csclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaskStore caches task summaries between synchronizations.
+// Backends with third-party dependencies (e.g. SQLite) belong in separate modules.
+type TaskStore interface {
+	// Get returns a cached task
+	Get(taskID string) (TaskSummaryDto, bool, error)
+	// Upsert inserts or replaces tasks
+	Upsert(tasks []TaskSummaryDto) error
+	// All returns every cached task ordered by creation time
+	All() ([]TaskSummaryDto, error)
+	// LastSync returns the time of the last successful synchronization
+	LastSync() (time.Time, error)
+	// SetLastSync records the time of a successful synchronization
+	SetLastSync(t time.Time) error
+}
+
+// MemoryTaskStore is an in-memory TaskStore
+type MemoryTaskStore struct {
+	mu       sync.RWMutex
+	tasks    map[string]TaskSummaryDto
+	lastSync time.Time
+}
+
+// NewMemoryTaskStore creates an empty in-memory task store
+func NewMemoryTaskStore() *MemoryTaskStore {
+	return &MemoryTaskStore{tasks: make(map[string]TaskSummaryDto)}
+}
+
+// Get returns a cached task
+func (s *MemoryTaskStore) Get(taskID string) (TaskSummaryDto, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[taskID]
+	return task, ok, nil
+}
+
+// Upsert inserts or replaces tasks
+func (s *MemoryTaskStore) Upsert(tasks []TaskSummaryDto) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, task := range tasks {
+		s.tasks[task.TaskID] = task
+	}
+	return nil
+}
+
+// All returns every cached task ordered by creation time
+func (s *MemoryTaskStore) All() ([]TaskSummaryDto, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := make([]TaskSummaryDto, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Created.Before(tasks[j].Created)
+	})
+	return tasks, nil
+}
+
+// LastSync returns the time of the last successful synchronization
+func (s *MemoryTaskStore) LastSync() (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastSync, nil
+}
+
+// SetLastSync records the time of a successful synchronization
+func (s *MemoryTaskStore) SetLastSync(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSync = t
+	return nil
+}
+
+// syncClockSkew is subtracted from the last sync time to tolerate clock differences
+const syncClockSkew = 30 * time.Second
+
+// SyncTasks brings the store up to date and returns the tasks that are new or
+// changed since the previous synchronization. After the first sync, the request
+// carries If-Modified-Since and an updatedSince parameter so servers that support
+// them only send changes; a 304 response means nothing changed.
+func (c *Client) SyncTasks(ctx context.Context, store TaskStore) ([]TaskSummaryDto, error) {
+	lastSync, err := store.LastSync()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task store: %w", err)
+	}
+	started := time.Now()
+
+	path := "/api/v1/tasks"
+	if !lastSync.IsZero() {
+		since := lastSync.Add(-syncClockSkew).UTC()
+		path = withQuery(path, url.Values{"updatedSince": {since.Format(time.RFC3339)}})
+		ctx = withRequestHeaders(ctx, http.Header{"If-Modified-Since": {since.Format(http.TimeFormat)}})
+	}
+
+	var tasks []TaskSummaryDto
+	if err := c.doRequest(ctx, "GET", path, nil, &tasks, true); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotModified {
+			return nil, store.SetLastSync(started)
+		}
+		return nil, fmt.Errorf("failed to sync tasks: %w", err)
+	}
+
+	var changed []TaskSummaryDto
+	for _, task := range tasks {
+		cached, ok, err := store.Get(task.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task store: %w", err)
+		}
+		if !ok || taskChanged(&cached, &task) {
+			changed = append(changed, task)
+		}
+	}
+
+	if err := store.Upsert(changed); err != nil {
+		return nil, fmt.Errorf("failed to update task store: %w", err)
+	}
+	if err := store.SetLastSync(started); err != nil {
+		return nil, fmt.Errorf("failed to update task store: %w", err)
+	}
+	return changed, nil
+}
+
+// taskChanged reports whether a fetched task differs from its cached copy
+func taskChanged(cached, fetched *TaskSummaryDto) bool {
+	if cached.TaskStatus != fetched.TaskStatus || cached.JID != fetched.JID {
+		return true
+	}
+	if (cached.Updated == nil) != (fetched.Updated == nil) {
+		return true
+	}
+	return cached.Updated != nil && !cached.Updated.Equal(*fetched.Updated)
+}
@@ -0,0 +1,93 @@
+package csclient
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Timeouts holds recommended per-operation request timeouts. They are applied
+// only when the caller's context has no deadline of its own.
+type Timeouts struct {
+	Default           time.Duration // Any request not covered below
+	Shell             time.Duration // Shell, run and PowerShell submissions
+	Upload            time.Duration // File uploads (large request bodies)
+	Download          time.Duration // Fetching downloaded files and screenshots
+	BOF               time.Duration // BOF submissions (object file in the request body)
+	Assembly          time.Duration // .NET assembly and post-ex DLL submissions
+	PayloadGeneration time.Duration // Payload generation
+	TaskWait          time.Duration // Waiting for task completion in the *AndWait helpers when no timeout is given
+}
+
+// DefaultTimeouts returns a timeout profile suited to typical team server deployments
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Default:           30 * time.Second,
+		Shell:             time.Minute,
+		Upload:            10 * time.Minute,
+		Download:          10 * time.Minute,
+		BOF:               2 * time.Minute,
+		Assembly:          5 * time.Minute,
+		PayloadGeneration: 5 * time.Minute,
+		TaskWait:          5 * time.Minute,
+	}
+}
+
+// SetTimeouts enables per-operation timeouts. Because a single http.Client
+// timeout would cut long operations short, the HTTP client's global timeout is
+// removed and each request is bounded by its operation's timeout instead.
+func (c *Client) SetTimeouts(timeouts Timeouts) {
+	client := *c.httpClient
+	client.Timeout = 0
+	c.httpClient = &client
+	c.timeouts = &timeouts
+}
+
+// timeoutFor returns the recommended timeout for a request path
+func (t *Timeouts) timeoutFor(path string) time.Duration {
+	var d time.Duration
+	switch {
+	case strings.Contains(path, "/execute/upload"):
+		d = t.Upload
+	case strings.HasPrefix(path, "/api/v1/data/downloads/"), strings.HasPrefix(path, "/api/v1/data/screenshots/"):
+		d = t.Download
+	case strings.Contains(path, "/execute/bof/"):
+		d = t.BOF
+	case strings.Contains(path, "/dotnetAssembly"), strings.Contains(path, "/postExDll"):
+		d = t.Assembly
+	case strings.HasPrefix(path, "/api/v1/payloads/"):
+		d = t.PayloadGeneration
+	case strings.Contains(path, "/command/"), strings.Contains(path, "/powershell"):
+		d = t.Shell
+	}
+	if d <= 0 {
+		d = t.Default
+	}
+	return d
+}
+
+// withOperationTimeout applies the recommended timeout for path when ctx has no deadline
+func (c *Client) withOperationTimeout(ctx context.Context, path string) (context.Context, context.CancelFunc) {
+	if c.timeouts == nil {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	d := c.timeouts.timeoutFor(path)
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// taskWaitTimeout returns timeout, or the profile's TaskWait when timeout is not positive
+func (c *Client) taskWaitTimeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	if c.timeouts != nil && c.timeouts.TaskWait > 0 {
+		return c.timeouts.TaskWait
+	}
+	return DefaultTimeouts().TaskWait
+}
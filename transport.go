@@ -0,0 +1,72 @@
+package csclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// HTTPVersion selects the HTTP protocol version negotiated with the team server
+type HTTPVersion int
+
+const (
+	HTTPVersionAuto HTTPVersion = iota // Let net/http negotiate (default)
+	HTTPVersion1                       // Force HTTP/1.1
+	HTTPVersion2                       // Attempt HTTP/2 even with a custom TLS config
+)
+
+// TransportOptions tunes connection handling without replacing the whole http.Client.
+// Zero values leave the corresponding setting unchanged.
+type TransportOptions struct {
+	MaxIdleConns        int           // Maximum idle connections across all hosts
+	MaxIdleConnsPerHost int           // Maximum idle connections kept per host
+	MaxConnsPerHost     int           // Maximum total connections per host
+	IdleConnTimeout     time.Duration // How long idle connections stay in the pool
+	TLSHandshakeTimeout time.Duration // Maximum time to wait for a TLS handshake
+	DisableKeepAlives   bool          // Use a new connection for every request
+	HTTPVersion         HTTPVersion   // Protocol version to use
+}
+
+// SetTransportOptions applies transport tuning to the client's current HTTP client.
+// If the current transport is not an *http.Transport, a clone of the default transport is used.
+func (c *Client) SetTransportOptions(opts TransportOptions) {
+	var transport *http.Transport
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	if opts.DisableKeepAlives {
+		transport.DisableKeepAlives = true
+	}
+
+	switch opts.HTTPVersion {
+	case HTTPVersion1:
+		// A non-nil empty map disables the automatic HTTP/2 upgrade
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	case HTTPVersion2:
+		transport.ForceAttemptHTTP2 = true
+		transport.TLSNextProto = nil
+	}
+
+	client := *c.httpClient
+	client.Transport = transport
+	c.httpClient = &client
+}
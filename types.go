@@ -7,6 +7,7 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Retryable  bool
+	NotSent    bool // The request never reached the server (e.g. connection refused)
 }
 
 func (e *APIError) Error() string {
@@ -47,6 +48,13 @@ const (
 	TaskStatusOutputReceived TaskStatus = "OUTPUT_RECEIVED"
 )
 
+// IsTerminal reports whether a task in this status has finished waiting for
+// its beacon. OUTPUT_RECEIVED counts as terminal, but long-running jobs may
+// still append output afterwards until they become COMPLETED or FAILED.
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusCompleted || s == TaskStatusOutputReceived || s == TaskStatusFailed
+}
+
 // TaskSummaryDto represents a task summary
 type TaskSummaryDto struct {
 	TaskID      string     `json:"taskId"`
@@ -62,11 +70,119 @@ type TaskSummaryDto struct {
 // TaskDetailDto represents detailed task information with outputs
 type TaskDetailDto struct {
 	TaskSummaryDto
-	Result  []map[string]interface{} `json:"result,omitempty"`
-	Error   []ErrorMessageDto        `json:"error,omitempty"`
-	Tactics []string                 `json:"tactics,omitempty"`
+	Result               []map[string]interface{} `json:"result,omitempty"`
+	Error                []ErrorMessageDto        `json:"error,omitempty"`
+	Tactics              []string                 `json:"tactics,omitempty"`
+	TaskAcknowledgements []TaskAckDto             `json:"taskAcknowledgements,omitempty"`
+}
+
+// TaskAckDto represents the acknowledgement of a task by the beacon
+type TaskAckDto struct {
+	Text      string    `json:"text"`
+	Tactics   []string  `json:"tactics"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OutputType is the discriminator of a task result entry
+type OutputType string
+
+const (
+	OutputTypeText            OutputType = "text"
+	OutputTypeLs              OutputType = "ls"
+	OutputTypePs              OutputType = "ps"
+	OutputTypeJobs            OutputType = "jobs"
+	OutputTypeTokenStore      OutputType = "tokenStore"
+	OutputTypeTokenStoreSteal OutputType = "tokenStoreSteal"
+)
+
+// TextOutputDto represents console output (shell, BOF, screenshot and download notices, etc.)
+type TextOutputDto struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Type      OutputType `json:"type"`
+	Output    string     `json:"output"`
+}
+
+func (TextOutputDto) OutputType() OutputType { return OutputTypeText }
+
+// FolderDto represents a directory listing result
+type FolderDto struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Type      OutputType       `json:"type"`
+	Folder    string           `json:"folder"`
+	Contents  []FolderEntryDto `json:"contents"`
+}
+
+func (FolderDto) OutputType() OutputType { return OutputTypeLs }
+
+// FolderEntryDto represents a single entry in a directory listing
+type FolderEntryDto struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Modified string `json:"modified"`
+	Size     int64  `json:"size"`
+}
+
+// ProcessListDto represents a process listing result
+type ProcessListDto struct {
+	Timestamp   time.Time    `json:"timestamp"`
+	Type        OutputType   `json:"type"`
+	ProcessList []ProcessDto `json:"processList"`
+}
+
+func (ProcessListDto) OutputType() OutputType { return OutputTypePs }
+
+// ProcessDto represents a single process in a process listing
+type ProcessDto struct {
+	Process string `json:"process"`
+	PPID    int    `json:"ppid"`
+	PID     int    `json:"pid"`
+	Arch    string `json:"arch,omitempty"`
+	User    string `json:"user,omitempty"`
+	SessID  string `json:"sessid,omitempty"`
 }
 
+// JobsInfoDto represents a job listing result
+type JobsInfoDto struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Type      OutputType   `json:"type"`
+	Jobs      []JobInfoDto `json:"jobs"`
+}
+
+func (JobsInfoDto) OutputType() OutputType { return OutputTypeJobs }
+
+// JobInfoDto represents a single beacon job
+type JobInfoDto struct {
+	JID         int    `json:"jid"`
+	PID         int    `json:"pid"`
+	Description string `json:"description"`
+}
+
+// TokenStoreDto represents the content of the token store
+type TokenStoreDto struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Type      OutputType `json:"type"`
+	Tokens    []TokenDto `json:"tokens"`
+}
+
+func (TokenStoreDto) OutputType() OutputType { return OutputTypeTokenStore }
+
+// TokenDto represents a token held in the token store
+type TokenDto struct {
+	ID   int    `json:"id"`
+	User string `json:"user"`
+}
+
+// TokenStoreStealOutputDto represents a token stolen into the token store
+type TokenStoreStealOutputDto struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Type      OutputType `json:"type"`
+	ID        int        `json:"id"`
+	PID       int        `json:"pid"`
+	User      string     `json:"user"`
+}
+
+func (TokenStoreStealOutputDto) OutputType() OutputType { return OutputTypeTokenStoreSteal }
+
 // ErrorMessageDto represents an error message
 type ErrorMessageDto struct {
 	Message string    `json:"message"`
@@ -79,6 +195,23 @@ type SleepDto struct {
 	Jitter int `json:"jitter"` // Jitter percentage (0-99)
 }
 
+// NoteDto represents a beacon note
+type NoteDto struct {
+	Note string `json:"note"`
+}
+
+// DNS beacon data channel modes
+const (
+	DnsModeA    = "dns"    // DNS A record data channel
+	DnsModeAAAA = "dns6"   // DNS AAAA record data channel
+	DnsModeTXT  = "dnsTxt" // DNS TXT record data channel (default)
+)
+
+// DnsModeDto represents a DNS beacon mode change
+type DnsModeDto struct {
+	Mode string `json:"mode"`
+}
+
 // BeaconDto represents beacon information
 type BeaconDto struct {
 	BID                  string    `json:"bid"`
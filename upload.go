@@ -0,0 +1,157 @@
+package csclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UploadOptions controls where an upload lands on the target
+type UploadOptions struct {
+	// Name is the remote file name; it defaults to the local file name. The
+	// API uses it as the file key, so it is limited to letters, digits, '.',
+	// '_' and '-'.
+	Name string
+	// Dir is the remote directory; empty uses the working directory. The beacon
+	// changes into Dir for the upload and back afterwards, which requires
+	// waiting for Pwd and the cd first.
+	Dir string
+}
+
+// UploadTo uploads a local file to an explicit remote path such as
+// C:\Windows\Temp\svc.exe, renaming it on the way
+func (c *Client) UploadTo(ctx context.Context, bid, localPath, remotePath string) (*AsyncCommandResponse, error) {
+	opts, err := remoteUploadOptions(remotePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	return c.UploadWithOptions(ctx, bid, localPath, opts)
+}
+
+// UploadBytes uploads in-memory data without writing it to local disk. name is
+// the remote file name, or a full remote path as accepted by UploadTo.
+func (c *Client) UploadBytes(ctx context.Context, bid, name string, data []byte) (*AsyncCommandResponse, error) {
+	opts, err := remoteUploadOptions(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	return c.uploadData(ctx, bid, data, opts)
+}
+
+// UploadReader uploads the content of r as UploadBytes does. The content is
+// read fully into memory, as the API takes the file base64-encoded in the request.
+func (c *Client) UploadReader(ctx context.Context, bid, name string, r io.Reader) (*AsyncCommandResponse, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload content: %w", err)
+	}
+	return c.UploadBytes(ctx, bid, name, data)
+}
+
+// remoteUploadOptions splits a remote path into its directory and file name
+func remoteUploadOptions(remotePath string) (UploadOptions, error) {
+	opts := UploadOptions{Name: remotePath}
+	if i := strings.LastIndexAny(remotePath, `\/`); i >= 0 {
+		opts.Dir, opts.Name = remotePath[:i], remotePath[i+1:]
+		if opts.Dir == "" || strings.HasSuffix(opts.Dir, ":") {
+			opts.Dir += `\`
+		}
+	}
+	if opts.Name == "" {
+		return opts, &ValidationError{Field: "remotePath", Value: remotePath, Reason: "must end with a file name"}
+	}
+	return opts, nil
+}
+
+// UploadWithOptions uploads a local file with a remote name and directory
+func (c *Client) UploadWithOptions(ctx context.Context, bid, localPath string, opts UploadOptions) (*AsyncCommandResponse, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if opts.Name == "" {
+		opts.Name = filepath.Base(localPath)
+	}
+	return c.uploadData(ctx, bid, data, opts)
+}
+
+// uploadData uploads data as opts.Name, changing into opts.Dir around the upload.
+// Pwd and the cd are waited on, so a directory that cannot be entered fails the
+// upload instead of leaving the file in the working directory; the upload and
+// cd back are queued in order.
+func (c *Client) uploadData(ctx context.Context, bid string, data []byte, opts UploadOptions) (*AsyncCommandResponse, error) {
+	if err := validateUploadName(opts.Name); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	var previous string
+	if opts.Dir != "" {
+		var err error
+		if previous, err = c.Pwd(ctx, bid); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		if err := c.enterDir(ctx, bid, opts.Dir); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+	}
+
+	var resp AsyncCommandResponse
+	path, err := beaconPath(bid, "/execute/upload")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	ref, files := attachFile(opts.Name, data)
+	uploadErr := c.doRequest(ctx, "POST", path, UploadDto{File: ref, Files: files}, &resp, true)
+
+	if previous != "" {
+		if _, err := c.Cd(ctx, bid, previous); err != nil && uploadErr == nil {
+			return &resp, fmt.Errorf("failed to restore working directory %s: %w", previous, err)
+		}
+	}
+	if uploadErr != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", uploadErr)
+	}
+	return &resp, nil
+}
+
+// enterDir changes the beacon into dir and waits for the cd, failing when the
+// beacon reports an error
+func (c *Client) enterDir(ctx context.Context, bid, dir string) error {
+	resp, err := c.Cd(ctx, bid, dir)
+	if err != nil {
+		return err
+	}
+	task, err := c.waitForResponse(ctx, resp, 0)
+	if err != nil {
+		return fmt.Errorf("failed to change directory to %s: %w", dir, err)
+	}
+	if task.TaskStatus == TaskStatusFailed {
+		return fmt.Errorf("failed to change directory to %s: task %s failed", dir, task.TaskID)
+	}
+	outputs, err := DecodeBOFOutput(task)
+	if err != nil {
+		return fmt.Errorf("failed to change directory to %s: %w", dir, err)
+	}
+	for _, out := range outputs {
+		if out.CallbackType == BOFCallbackError {
+			return fmt.Errorf("failed to change directory to %s: %s", dir, strings.TrimSpace(out.Text()))
+		}
+	}
+	return nil
+}
+
+// validateUploadName rejects remote names attachFile would have to rewrite
+func validateUploadName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return &ValidationError{Field: "name", Value: name, Reason: "must be a file name"}
+	}
+	for _, ch := range name {
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '.' || ch == '_' || ch == '-') {
+			return &ValidationError{Field: "name", Value: name, Reason: "may only contain letters, digits, '.', '_' and '-'"}
+		}
+	}
+	return nil
+}
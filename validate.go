@@ -0,0 +1,75 @@
+package csclient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidationError is returned when a request parameter is rejected before it is sent
+type ValidationError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// escapePathParam validates a value that is interpolated into a URL path and
+// returns it escaped. Values that could change the meaning of the path
+// (separators, dot segments, control characters) are rejected.
+func escapePathParam(field, value string) (string, error) {
+	if value == "" {
+		return "", &ValidationError{Field: field, Value: value, Reason: "must not be empty"}
+	}
+	if value == "." || value == ".." {
+		return "", &ValidationError{Field: field, Value: value, Reason: "must not be a dot segment"}
+	}
+	if strings.ContainsAny(value, "/\\?#%") {
+		return "", &ValidationError{Field: field, Value: value, Reason: "contains reserved path characters"}
+	}
+	for _, r := range value {
+		if r < 0x20 || r == 0x7f {
+			return "", &ValidationError{Field: field, Value: value, Reason: "contains control characters"}
+		}
+	}
+	return url.PathEscape(value), nil
+}
+
+// validateRemoteHost accepts host names, FQDNs and IP addresses (optionally
+// prefixed with \\) for the remote target of a Windows utility, so a target
+// cannot add switches or further commands to its command line
+func validateRemoteHost(target string) error {
+	host := strings.TrimPrefix(target, `\\`)
+	valid := host != "" && !strings.HasPrefix(host, "-")
+	for _, ch := range host {
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '.' || ch == '-' || ch == '_' || ch == ':') {
+			valid = false
+			break
+		}
+	}
+	if !valid {
+		return &ValidationError{Field: "target", Value: target, Reason: "must be a host name or IP address"}
+	}
+	return nil
+}
+
+// beaconPath builds an API path for a beacon endpoint, e.g. beaconPath(bid, "/execute/getUid")
+func beaconPath(bid string, suffix string) (string, error) {
+	escaped, err := escapePathParam("beacon ID", bid)
+	if err != nil {
+		return "", err
+	}
+	return "/api/v1/beacons/" + escaped + suffix, nil
+}
+
+// taskPath builds an API path for a task endpoint, e.g. taskPath(taskID, "")
+func taskPath(taskID string, suffix string) (string, error) {
+	escaped, err := escapePathParam("task ID", taskID)
+	if err != nil {
+		return "", err
+	}
+	return "/api/v1/tasks/" + escaped + suffix, nil
+}
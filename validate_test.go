@@ -0,0 +1,26 @@
+package csclient
+
+import "testing"
+
+func TestValidateRemoteHost(t *testing.T) {
+	tests := []struct {
+		target string
+		valid  bool
+	}{
+		{"dc01", true},
+		{`\\dc01.corp.local`, true},
+		{"10.0.0.5", true},
+		{"fe80::1", true},
+		{"", false},
+		{`\\`, false},
+		{"-dc01", false},
+		{"dc01 create evil binPath= x", false},
+		{"dc01 & calc", false},
+		{`dc01"`, false},
+	}
+	for _, tt := range tests {
+		if err := validateRemoteHost(tt.target); (err == nil) != tt.valid {
+			t.Errorf("validateRemoteHost(%q) = %v, want valid %v", tt.target, err, tt.valid)
+		}
+	}
+}